@@ -23,15 +23,18 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/api/resource"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
 	"github.com/cert-manager/cert-manager/controller-binary/app/options"
@@ -45,6 +48,7 @@ import (
 	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/profiling"
 )
@@ -72,11 +76,10 @@ func Run(opts *config.ControllerConfiguration, stopCh <-chan struct{}) error {
 	log.Info(fmt.Sprintf("enabled controllers: %s", enabledControllers.List()))
 
 	// Start metrics server
-	metricsLn, err := net.Listen("tcp", opts.MetricsListenAddress)
+	metricsServer, metricsLn, err := ctx.Metrics.NewServerE(opts.MetricsListenAddress)
 	if err != nil {
-		return fmt.Errorf("failed to listen on prometheus address %s: %v", opts.MetricsListenAddress, err)
+		return err
 	}
-	metricsServer := ctx.Metrics.NewServer(metricsLn)
 
 	g.Go(func() error {
 		<-rootCtx.Done()
@@ -97,6 +100,17 @@ func Run(opts *config.ControllerConfiguration, stopCh <-chan struct{}) error {
 		return nil
 	})
 
+	// Log a snapshot of the metrics on request, for debugging
+	if len(metricsSnapshotSignals) > 0 {
+		snapshotSignals := make(chan os.Signal, 1)
+		signal.Notify(snapshotSignals, metricsSnapshotSignals...)
+		g.Go(func() error {
+			defer signal.Stop(snapshotSignals)
+			logMetricsSnapshots(rootCtx, ctx.Metrics, snapshotSignals)
+			return nil
+		})
+	}
+
 	// Start profiler if it is enabled
 	if opts.EnablePprof {
 		profilerLn, err := net.Listen("tcp", opts.PprofAddress)
@@ -273,6 +287,40 @@ func buildControllerContextFactory(ctx context.Context, opts *config.ControllerC
 	ACMEHTTP01SolverRunAsNonRoot := opts.ACMEHTTP01Config.SolverRunAsNonRoot
 	acmeAccountRegistry := accounts.NewDefaultRegistry()
 
+	metricsOpts := []metrics.Option{
+		metrics.WithCertificateReadyStatusReason(opts.EnableCertificateReadyStatusReason),
+		metrics.WithVaultIssuanceLabels(opts.EnableVaultIssuanceLabels),
+		metrics.WithMinimumRSAKeySize(opts.MetricsMinimumRSAKeySize),
+	}
+	if opts.EnableMetricsZeroValuedSeries {
+		metricsOpts = append(metricsOpts, metrics.WithZeroValuedSeries(options.EnabledControllers(opts).List()...))
+	}
+	if opts.MetricsAuthentication.Enabled {
+		restConfig, err := clientcmd.BuildConfigFromFlags(opts.APIServerHost, opts.KubeConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error creating rest config for metrics authentication: %w", err)
+		}
+		cl, err := kubernetes.NewForConfig(util.RestConfigWithUserAgent(restConfig, "metrics"))
+		if err != nil {
+			return nil, fmt.Errorf("error creating kubernetes client for metrics authentication: %w", err)
+		}
+		metricsOpts = append(metricsOpts, metrics.WithTokenReviewAuthentication(metrics.TokenReviewAuthentication{
+			TokenReviews: cl.AuthenticationV1().TokenReviews(),
+			HeaderName:   opts.MetricsAuthentication.TokenHeaderName,
+			Audiences:    opts.MetricsAuthentication.Audiences,
+		}))
+		// Requests to the admin endpoints are authorized with
+		// SubjectAccessReviews on top of being authenticated.
+		if opts.EnableMetricsAdminEndpoints {
+			metricsOpts = append(metricsOpts, metrics.WithAdminEndpoints(cl.AuthorizationV1().SubjectAccessReviews()))
+		}
+	}
+	controllerMetrics := metrics.New(log, clock.RealClock{}, metricsOpts...)
+	controllerMetrics.SetLoggingVerbosity(uint32(opts.Logging.Verbosity))
+	// The workqueue metrics provider must be set before any of the
+	// controllers' workqueues are created.
+	workqueue.SetProvider(controllerMetrics.WorkqueueMetricsProvider())
+
 	ctxFactory, err := controller.NewContextFactory(ctx, controller.ContextOptions{
 		Kubeconfig:         opts.KubeConfig,
 		KubernetesAPIQPS:   opts.KubernetesAPIQPS,
@@ -282,7 +330,7 @@ func buildControllerContextFactory(ctx context.Context, opts *config.ControllerC
 		Namespace: opts.Namespace,
 
 		Clock:   clock.RealClock{},
-		Metrics: metrics.New(log, clock.RealClock{}),
+		Metrics: controllerMetrics,
 
 		ACMEOptions: controller.ACMEOptions{
 			HTTP01SolverResourceRequestCPU:    http01SolverResourceRequestCPU,
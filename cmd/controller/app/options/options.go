@@ -184,6 +184,32 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.ControllerConfiguration) {
 
 	fs.StringVar(&c.MetricsListenAddress, "metrics-listen-address", c.MetricsListenAddress, ""+
 		"The host and port that the metrics endpoint should listen on.")
+	fs.BoolVar(&c.EnableCertificateReadyStatusReason, "enable-certificate-ready-status-reason", c.EnableCertificateReadyStatusReason, ""+
+		"Whether to add the reason of a Certificate's Ready condition as a label on the certificate_ready_status metric. "+
+		"This increases the number of series exposed for each Certificate.")
+	fs.BoolVar(&c.EnableVaultIssuanceLabels, "enable-vault-issuance-labels", c.EnableVaultIssuanceLabels, ""+
+		"Whether to label the vault_issuance_count metric with the Vault PKI role and path used to sign each certificate. "+
+		"Disable this if there are many distinct roles or paths.")
+	fs.IntVar(&c.MetricsMinimumRSAKeySize, "metrics-minimum-rsa-key-size", c.MetricsMinimumRSAKeySize, ""+
+		"The minimum size, in bits, of an RSA key below which a Certificate's key is counted as weak in the "+
+		"certificate_weak_key_count metric.")
+	fs.BoolVar(&c.EnableMetricsZeroValuedSeries, "enable-metrics-zero-valued-series", c.EnableMetricsZeroValuedSeries, ""+
+		"Whether to expose zero-valued series for the per-controller and DNS01 provider counters before they are first incremented, "+
+		"so that alerts on their rate do not report no data.")
+	fs.BoolVar(&c.EnableMetricsAdminEndpoints, "enable-metrics-admin-endpoints", c.EnableMetricsAdminEndpoints, ""+
+		"Whether to serve POST /admin/metrics/<name>/disable and POST /admin/metrics/<name>/enable on the metrics endpoint, "+
+		"which disable and re-enable individual metrics at runtime. "+
+		"Requests are authenticated in the same way as scrapes, so this requires --enable-metrics-authentication, and are authorized "+
+		"with a SubjectAccessReview for the post verb on the request path, e.g. granted by a ClusterRole rule for the non-resource URL /admin/metrics/*. "+
+		"Disabling a metric can silence alerts, so never grant this to users which may not change monitoring.")
+	fs.BoolVar(&c.MetricsAuthentication.Enabled, "enable-metrics-authentication", c.MetricsAuthentication.Enabled, ""+
+		"Whether scrapes of the metrics endpoint must present a bearer token, which is validated using the Kubernetes TokenReview API. "+
+		"Scrapes with a missing or invalid token are refused with 401 Unauthorized.")
+	fs.StringVar(&c.MetricsAuthentication.TokenHeaderName, "metrics-authentication-token-header", c.MetricsAuthentication.TokenHeaderName, ""+
+		"The request header which carries the bearer token of metrics scrapes, with an optional 'Bearer ' prefix.")
+	fs.StringSliceVar(&c.MetricsAuthentication.Audiences, "metrics-authentication-audiences", c.MetricsAuthentication.Audiences, ""+
+		"The audiences which the bearer token of metrics scrapes must be valid for. "+
+		"If not set, the audiences of the API server are used.")
 	fs.BoolVar(&c.EnablePprof, "enable-profiling", c.EnablePprof, ""+
 		"Enable profiling for controller.")
 	fs.StringVar(&c.PprofAddress, "profiler-address", c.PprofAddress,
@@ -0,0 +1,40 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"os"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+// logMetricsSnapshots logs a snapshot of the controller's metrics each time a
+// signal is received, until the context is done. This allows the metrics to
+// be inspected when debugging without scraping the metrics server.
+func logMetricsSnapshots(ctx context.Context, m *metrics.Metrics, signals <-chan os.Signal) {
+	log := logf.FromContext(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-signals:
+			log.Info("metrics snapshot", "signal", sig.String(), "metrics", m.Snapshot())
+		}
+	}
+}
@@ -62,9 +62,17 @@ var Funcs = func(codecs runtimeserializer.CodecFactory) []interface{} {
 			s.ACMEDNS01Config.RecursiveNameservers = []string{"8.8.8.8:53"}
 			s.ACMEDNS01Config.RecursiveNameserversOnly = true
 			s.EnableCertificateOwnerRef = true
+			s.EnableCertificateReadyStatusReason = true
+			s.EnableVaultIssuanceLabels = true
+			s.MetricsMinimumRSAKeySize = 2048
+			s.EnableMetricsZeroValuedSeries = true
 			s.NumberOfConcurrentWorkers = 1
 			s.MaxConcurrentChallenges = 1
 			s.MetricsListenAddress = "0.0.0.0:9402"
+			s.EnableMetricsAdminEndpoints = true
+			s.MetricsAuthentication.Enabled = true
+			s.MetricsAuthentication.TokenHeaderName = "Authorization"
+			s.MetricsAuthentication.Audiences = []string{"cert-manager-metrics"}
 			s.HealthzListenAddress = "0.0.0.0:9402"
 			s.LeaderElectionConfig.HealthzTimeout = defaultTime
 			s.EnablePprof = true
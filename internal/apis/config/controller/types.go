@@ -96,6 +96,35 @@ type ControllerConfiguration struct {
 	// The host and port that the metrics endpoint should listen on.
 	MetricsListenAddress string
 
+	// Whether to add the reason of a Certificate's Ready condition as a label
+	// on the certificate_ready_status metric.
+	EnableCertificateReadyStatusReason bool
+
+	// Whether to label the vault_issuance_count metric with the Vault PKI role
+	// and path used to sign each certificate.
+	EnableVaultIssuanceLabels bool
+
+	// The minimum size, in bits, of an RSA key below which a Certificate's key
+	// is counted in the certificate_weak_key_count metric.
+	MetricsMinimumRSAKeySize int
+
+	// Whether to pre-populate per-controller and DNS01 provider counters with
+	// zero-valued series when the controller starts.
+	EnableMetricsZeroValuedSeries bool
+
+	// Whether to serve the metrics admin endpoints, which allow individual
+	// metrics to be disabled and re-enabled at runtime. Requests are
+	// authenticated in the same way as scrapes, so this requires
+	// MetricsAuthentication to be enabled, and are authorized with a
+	// SubjectAccessReview for the post verb on the request path, e.g.
+	// `/admin/metrics/*`. Disabling a metric can silence alerts, so only
+	// grant this to users which may change monitoring.
+	EnableMetricsAdminEndpoints bool
+
+	// MetricsAuthentication configures authentication of scrapes of the
+	// metrics endpoint
+	MetricsAuthentication MetricsAuthenticationConfig
+
 	// The host and port address, separated by a ':', that the healthz server
 	// should listen on.
 	HealthzListenAddress string
@@ -156,6 +185,20 @@ type LeaderElectionConfig struct {
 	HealthzTimeout time.Duration
 }
 
+type MetricsAuthenticationConfig struct {
+	// If true, scrapes of the metrics endpoint must present a bearer token,
+	// which is validated using the Kubernetes TokenReview API
+	Enabled bool
+
+	// The request header which carries the bearer token, with an optional
+	// `Bearer ` prefix
+	TokenHeaderName string
+
+	// The audiences which the bearer token must be valid for. If empty, the
+	// audiences of the API server are used.
+	Audiences []string
+}
+
 type IngressShimConfig struct {
 	// Default issuer/certificates details consumed by ingress-shim
 	// Name of the Issuer to use when the tls is requested but issuer name is
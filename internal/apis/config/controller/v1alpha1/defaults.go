@@ -79,6 +79,16 @@ var (
 	defaultTLSACMEIssuerGroup        = cm.GroupName
 	defaultEnableCertificateOwnerRef = false
 
+	defaultEnableCertificateReadyStatusReason = false
+	defaultEnableVaultIssuanceLabels          = true
+	defaultEnableMetricsZeroValuedSeries      = false
+	defaultEnableMetricsAdminEndpoints        = false
+
+	defaultMetricsMinimumRSAKeySize int32 = 2048
+
+	defaultEnableMetricsAuthentication          = false
+	defaultMetricsAuthenticationTokenHeaderName = "Authorization"
+
 	defaultDNS01RecursiveNameserversOnly = false
 	defaultDNS01RecursiveNameservers     = []string{}
 	defaultDNS01CheckRetryPeriod         = 10 * time.Second
@@ -229,6 +239,26 @@ func SetDefaults_ControllerConfiguration(obj *v1alpha1.ControllerConfiguration)
 		obj.MetricsListenAddress = defaultPrometheusMetricsServerAddress
 	}
 
+	if obj.EnableCertificateReadyStatusReason == nil {
+		obj.EnableCertificateReadyStatusReason = &defaultEnableCertificateReadyStatusReason
+	}
+
+	if obj.EnableVaultIssuanceLabels == nil {
+		obj.EnableVaultIssuanceLabels = &defaultEnableVaultIssuanceLabels
+	}
+
+	if obj.MetricsMinimumRSAKeySize == nil {
+		obj.MetricsMinimumRSAKeySize = &defaultMetricsMinimumRSAKeySize
+	}
+
+	if obj.EnableMetricsZeroValuedSeries == nil {
+		obj.EnableMetricsZeroValuedSeries = &defaultEnableMetricsZeroValuedSeries
+	}
+
+	if obj.EnableMetricsAdminEndpoints == nil {
+		obj.EnableMetricsAdminEndpoints = &defaultEnableMetricsAdminEndpoints
+	}
+
 	if obj.HealthzListenAddress == "" {
 		obj.HealthzListenAddress = defaultHealthzServerAddress
 	}
@@ -271,6 +301,16 @@ func SetDefaults_LeaderElectionConfig(obj *v1alpha1.LeaderElectionConfig) {
 	}
 }
 
+func SetDefaults_MetricsAuthenticationConfig(obj *v1alpha1.MetricsAuthenticationConfig) {
+	if obj.Enabled == nil {
+		obj.Enabled = &defaultEnableMetricsAuthentication
+	}
+
+	if obj.TokenHeaderName == "" {
+		obj.TokenHeaderName = defaultMetricsAuthenticationTokenHeaderName
+	}
+}
+
 func SetDefaults_IngressShimConfig(obj *v1alpha1.IngressShimConfig) {
 	if obj.DefaultIssuerName == "" {
 		obj.DefaultIssuerName = defaultTLSACMEIssuerName
@@ -89,6 +89,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.MetricsAuthenticationConfig)(nil), (*controller.MetricsAuthenticationConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MetricsAuthenticationConfig_To_controller_MetricsAuthenticationConfig(a.(*v1alpha1.MetricsAuthenticationConfig), b.(*controller.MetricsAuthenticationConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*controller.MetricsAuthenticationConfig)(nil), (*v1alpha1.MetricsAuthenticationConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_controller_MetricsAuthenticationConfig_To_v1alpha1_MetricsAuthenticationConfig(a.(*controller.MetricsAuthenticationConfig), b.(*v1alpha1.MetricsAuthenticationConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((**float32)(nil), (*float32)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_Pointer_float32_To_float32(a.(**float32), b.(*float32), scope)
 	}); err != nil {
@@ -208,6 +218,24 @@ func autoConvert_v1alpha1_ControllerConfiguration_To_controller_ControllerConfig
 		return err
 	}
 	out.MetricsListenAddress = in.MetricsListenAddress
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableCertificateReadyStatusReason, &out.EnableCertificateReadyStatusReason, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableVaultIssuanceLabels, &out.EnableVaultIssuanceLabels, s); err != nil {
+		return err
+	}
+	if err := Convert_Pointer_int32_To_int(&in.MetricsMinimumRSAKeySize, &out.MetricsMinimumRSAKeySize, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableMetricsZeroValuedSeries, &out.EnableMetricsZeroValuedSeries, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableMetricsAdminEndpoints, &out.EnableMetricsAdminEndpoints, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_MetricsAuthenticationConfig_To_controller_MetricsAuthenticationConfig(&in.MetricsAuthentication, &out.MetricsAuthentication, s); err != nil {
+		return err
+	}
 	out.HealthzListenAddress = in.HealthzListenAddress
 	if err := v1.Convert_Pointer_bool_To_bool(&in.EnablePprof, &out.EnablePprof, s); err != nil {
 		return err
@@ -264,6 +292,24 @@ func autoConvert_controller_ControllerConfiguration_To_v1alpha1_ControllerConfig
 		return err
 	}
 	out.MetricsListenAddress = in.MetricsListenAddress
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableCertificateReadyStatusReason, &out.EnableCertificateReadyStatusReason, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableVaultIssuanceLabels, &out.EnableVaultIssuanceLabels, s); err != nil {
+		return err
+	}
+	if err := Convert_int_To_Pointer_int32(&in.MetricsMinimumRSAKeySize, &out.MetricsMinimumRSAKeySize, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableMetricsZeroValuedSeries, &out.EnableMetricsZeroValuedSeries, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableMetricsAdminEndpoints, &out.EnableMetricsAdminEndpoints, s); err != nil {
+		return err
+	}
+	if err := Convert_controller_MetricsAuthenticationConfig_To_v1alpha1_MetricsAuthenticationConfig(&in.MetricsAuthentication, &out.MetricsAuthentication, s); err != nil {
+		return err
+	}
 	out.HealthzListenAddress = in.HealthzListenAddress
 	if err := v1.Convert_bool_To_Pointer_bool(&in.EnablePprof, &out.EnablePprof, s); err != nil {
 		return err
@@ -347,3 +393,31 @@ func autoConvert_controller_LeaderElectionConfig_To_v1alpha1_LeaderElectionConfi
 func Convert_controller_LeaderElectionConfig_To_v1alpha1_LeaderElectionConfig(in *controller.LeaderElectionConfig, out *v1alpha1.LeaderElectionConfig, s conversion.Scope) error {
 	return autoConvert_controller_LeaderElectionConfig_To_v1alpha1_LeaderElectionConfig(in, out, s)
 }
+
+func autoConvert_v1alpha1_MetricsAuthenticationConfig_To_controller_MetricsAuthenticationConfig(in *v1alpha1.MetricsAuthenticationConfig, out *controller.MetricsAuthenticationConfig, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Enabled, &out.Enabled, s); err != nil {
+		return err
+	}
+	out.TokenHeaderName = in.TokenHeaderName
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_v1alpha1_MetricsAuthenticationConfig_To_controller_MetricsAuthenticationConfig is an autogenerated conversion function.
+func Convert_v1alpha1_MetricsAuthenticationConfig_To_controller_MetricsAuthenticationConfig(in *v1alpha1.MetricsAuthenticationConfig, out *controller.MetricsAuthenticationConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MetricsAuthenticationConfig_To_controller_MetricsAuthenticationConfig(in, out, s)
+}
+
+func autoConvert_controller_MetricsAuthenticationConfig_To_v1alpha1_MetricsAuthenticationConfig(in *controller.MetricsAuthenticationConfig, out *v1alpha1.MetricsAuthenticationConfig, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Enabled, &out.Enabled, s); err != nil {
+		return err
+	}
+	out.TokenHeaderName = in.TokenHeaderName
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_controller_MetricsAuthenticationConfig_To_v1alpha1_MetricsAuthenticationConfig is an autogenerated conversion function.
+func Convert_controller_MetricsAuthenticationConfig_To_v1alpha1_MetricsAuthenticationConfig(in *controller.MetricsAuthenticationConfig, out *v1alpha1.MetricsAuthenticationConfig, s conversion.Scope) error {
+	return autoConvert_controller_MetricsAuthenticationConfig_To_v1alpha1_MetricsAuthenticationConfig(in, out, s)
+}
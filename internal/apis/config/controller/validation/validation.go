@@ -47,6 +47,10 @@ func ValidateControllerConfiguration(o *config.ControllerConfiguration) error {
 		return fmt.Errorf("invalid value for kube-api-burst: %v must be higher or equal to kube-api-qps: %v", o.KubernetesAPIQPS, o.KubernetesAPIQPS)
 	}
 
+	if o.EnableMetricsAdminEndpoints && !o.MetricsAuthentication.Enabled {
+		return errors.New("the --enable-metrics-admin-endpoints flag requires --enable-metrics-authentication")
+	}
+
 	for _, server := range o.ACMEHTTP01Config.SolverNameservers {
 		// ensure all servers have a port number
 		_, _, err := net.SplitHostPort(server)
@@ -82,6 +82,7 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.MetricsAuthentication.DeepCopyInto(&out.MetricsAuthentication)
 	in.Logging.DeepCopyInto(&out.Logging)
 	if in.FeatureGates != nil {
 		in, out := &in.FeatureGates, &out.FeatureGates
@@ -150,3 +151,24 @@ func (in *LeaderElectionConfig) DeepCopy() *LeaderElectionConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsAuthenticationConfig) DeepCopyInto(out *MetricsAuthenticationConfig) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsAuthenticationConfig.
+func (in *MetricsAuthenticationConfig) DeepCopy() *MetricsAuthenticationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsAuthenticationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
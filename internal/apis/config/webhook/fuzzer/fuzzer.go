@@ -17,6 +17,8 @@ limitations under the License.
 package fuzzer
 
 import (
+	"time"
+
 	fuzz "github.com/google/gofuzz"
 	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
 	logsapi "k8s.io/component-base/logs/api/v1"
@@ -33,6 +35,12 @@ var Funcs = func(codecs runtimeserializer.CodecFactory) []interface{} {
 			if s.PprofAddress == "" {
 				s.PprofAddress = "something:1234"
 			}
+			if s.SlowRequestThreshold == 0 {
+				s.SlowRequestThreshold = time.Second
+			}
+			if s.MetricsAuthentication.TokenHeaderName == "" {
+				s.MetricsAuthentication.TokenHeaderName = "Authorization"
+			}
 
 			logsapi.SetRecommendedLoggingConfiguration(&s.Logging)
 		},
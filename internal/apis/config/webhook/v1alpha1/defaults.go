@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/runtime"
 	logsapi "k8s.io/component-base/logs/api/v1"
 	"k8s.io/utils/pointer"
@@ -38,6 +40,15 @@ func SetDefaults_WebhookConfiguration(obj *v1alpha1.WebhookConfiguration) {
 	if obj.PprofAddress == "" {
 		obj.PprofAddress = "localhost:6060"
 	}
+	if obj.SlowRequestThreshold == time.Duration(0) {
+		obj.SlowRequestThreshold = time.Second
+	}
 
 	logsapi.SetRecommendedLoggingConfiguration(&obj.Logging)
 }
+
+func SetDefaults_MetricsAuthenticationConfig(obj *v1alpha1.MetricsAuthenticationConfig) {
+	if obj.TokenHeaderName == "" {
+		obj.TokenHeaderName = "Authorization"
+	}
+}
@@ -22,6 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	time "time"
 	unsafe "unsafe"
 
 	webhook "github.com/cert-manager/cert-manager/internal/apis/config/webhook"
@@ -58,6 +59,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.MetricsAuthenticationConfig)(nil), (*webhook.MetricsAuthenticationConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MetricsAuthenticationConfig_To_webhook_MetricsAuthenticationConfig(a.(*v1alpha1.MetricsAuthenticationConfig), b.(*webhook.MetricsAuthenticationConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*webhook.MetricsAuthenticationConfig)(nil), (*v1alpha1.MetricsAuthenticationConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_webhook_MetricsAuthenticationConfig_To_v1alpha1_MetricsAuthenticationConfig(a.(*webhook.MetricsAuthenticationConfig), b.(*v1alpha1.MetricsAuthenticationConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.TLSConfig)(nil), (*webhook.TLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_TLSConfig_To_webhook_TLSConfig(a.(*v1alpha1.TLSConfig), b.(*webhook.TLSConfig), scope)
 	}); err != nil {
@@ -127,6 +138,30 @@ func Convert_webhook_FilesystemServingConfig_To_v1alpha1_FilesystemServingConfig
 	return autoConvert_webhook_FilesystemServingConfig_To_v1alpha1_FilesystemServingConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_MetricsAuthenticationConfig_To_webhook_MetricsAuthenticationConfig(in *v1alpha1.MetricsAuthenticationConfig, out *webhook.MetricsAuthenticationConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.TokenHeaderName = in.TokenHeaderName
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_v1alpha1_MetricsAuthenticationConfig_To_webhook_MetricsAuthenticationConfig is an autogenerated conversion function.
+func Convert_v1alpha1_MetricsAuthenticationConfig_To_webhook_MetricsAuthenticationConfig(in *v1alpha1.MetricsAuthenticationConfig, out *webhook.MetricsAuthenticationConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MetricsAuthenticationConfig_To_webhook_MetricsAuthenticationConfig(in, out, s)
+}
+
+func autoConvert_webhook_MetricsAuthenticationConfig_To_v1alpha1_MetricsAuthenticationConfig(in *webhook.MetricsAuthenticationConfig, out *v1alpha1.MetricsAuthenticationConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.TokenHeaderName = in.TokenHeaderName
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_webhook_MetricsAuthenticationConfig_To_v1alpha1_MetricsAuthenticationConfig is an autogenerated conversion function.
+func Convert_webhook_MetricsAuthenticationConfig_To_v1alpha1_MetricsAuthenticationConfig(in *webhook.MetricsAuthenticationConfig, out *v1alpha1.MetricsAuthenticationConfig, s conversion.Scope) error {
+	return autoConvert_webhook_MetricsAuthenticationConfig_To_v1alpha1_MetricsAuthenticationConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_TLSConfig_To_webhook_TLSConfig(in *v1alpha1.TLSConfig, out *webhook.TLSConfig, s conversion.Scope) error {
 	out.CipherSuites = *(*[]string)(unsafe.Pointer(&in.CipherSuites))
 	out.MinTLSVersion = in.MinTLSVersion
@@ -168,6 +203,12 @@ func autoConvert_v1alpha1_WebhookConfiguration_To_webhook_WebhookConfiguration(i
 	if err := v1.Convert_Pointer_int32_To_int32(&in.HealthzPort, &out.HealthzPort, s); err != nil {
 		return err
 	}
+	out.MetricsListenAddress = in.MetricsListenAddress
+	out.ServeMetricsOnSecurePort = in.ServeMetricsOnSecurePort
+	if err := Convert_v1alpha1_MetricsAuthenticationConfig_To_webhook_MetricsAuthenticationConfig(&in.MetricsAuthentication, &out.MetricsAuthentication, s); err != nil {
+		return err
+	}
+	out.SlowRequestThreshold = time.Duration(in.SlowRequestThreshold)
 	if err := Convert_v1alpha1_TLSConfig_To_webhook_TLSConfig(&in.TLSConfig, &out.TLSConfig, s); err != nil {
 		return err
 	}
@@ -192,6 +233,12 @@ func autoConvert_webhook_WebhookConfiguration_To_v1alpha1_WebhookConfiguration(i
 	if err := v1.Convert_int32_To_Pointer_int32(&in.HealthzPort, &out.HealthzPort, s); err != nil {
 		return err
 	}
+	out.MetricsListenAddress = in.MetricsListenAddress
+	out.ServeMetricsOnSecurePort = in.ServeMetricsOnSecurePort
+	if err := Convert_webhook_MetricsAuthenticationConfig_To_v1alpha1_MetricsAuthenticationConfig(&in.MetricsAuthentication, &out.MetricsAuthentication, s); err != nil {
+		return err
+	}
+	out.SlowRequestThreshold = time.Duration(in.SlowRequestThreshold)
 	if err := Convert_webhook_TLSConfig_To_v1alpha1_TLSConfig(&in.TLSConfig, &out.TLSConfig, s); err != nil {
 		return err
 	}
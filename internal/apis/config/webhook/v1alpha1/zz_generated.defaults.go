@@ -36,4 +36,5 @@ func RegisterDefaults(scheme *runtime.Scheme) error {
 
 func SetObjectDefaults_WebhookConfiguration(in *v1alpha1.WebhookConfiguration) {
 	SetDefaults_WebhookConfiguration(in)
+	SetDefaults_MetricsAuthenticationConfig(&in.MetricsAuthentication)
 }
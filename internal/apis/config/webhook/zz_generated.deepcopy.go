@@ -62,6 +62,27 @@ func (in *FilesystemServingConfig) DeepCopy() *FilesystemServingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsAuthenticationConfig) DeepCopyInto(out *MetricsAuthenticationConfig) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsAuthenticationConfig.
+func (in *MetricsAuthenticationConfig) DeepCopy() *MetricsAuthenticationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsAuthenticationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 	*out = *in
@@ -89,6 +110,7 @@ func (in *TLSConfig) DeepCopy() *TLSConfig {
 func (in *WebhookConfiguration) DeepCopyInto(out *WebhookConfiguration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	in.MetricsAuthentication.DeepCopyInto(&out.MetricsAuthentication)
 	in.TLSConfig.DeepCopyInto(&out.TLSConfig)
 	in.Logging.DeepCopyInto(&out.Logging)
 	if in.FeatureGates != nil {
@@ -0,0 +1,67 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"math"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const (
+	// initialBackoffDelay is the backoff period after the first failed
+	// issuance attempt.
+	initialBackoffDelay = time.Hour
+	// stopIncreaseBackoff is the number of issuance attempts after which the backoff period should stop to increase
+	stopIncreaseBackoff = 6 // 2 ^ (6 - 1) = 32 = maxBackoffDelay
+	// maxBackoffDelay is the maximum backoff period
+	maxBackoffDelay = 32 * time.Hour
+)
+
+// IssuanceBackoffDelay returns how long after its last failed issuance
+// attempt a Certificate should wait before issuance is retried. The delay
+// doubles with each failed attempt, from 1 hour up to 32 hours.
+func IssuanceBackoffDelay(crt *cmapi.Certificate) time.Duration {
+	delay := initialBackoffDelay
+	failedIssuanceAttempts := 0
+	// It is possible that crt.Status.LastFailureTime != nil &&
+	// crt.Status.FailedIssuanceAttempts == nil (in case of the Certificate having
+	// failed for an installation of cert-manager before the issuance
+	// attempts were introduced). In such case delay = initialBackoffDelay.
+	if crt.Status.FailedIssuanceAttempts != nil {
+		failedIssuanceAttempts = *crt.Status.FailedIssuanceAttempts
+		delay = time.Hour * time.Duration(math.Pow(2, float64(failedIssuanceAttempts-1)))
+	}
+
+	// Ensure that maximum returned delay is 32 hours
+	// delay cannot be calculated for large issuance numbers, so we
+	// cannot reliably check if delay > maxBackoffDelay directly
+	// (see i.e the result of time.Duration(math.Pow(2, 99)))
+	if failedIssuanceAttempts > stopIncreaseBackoff {
+		delay = maxBackoffDelay
+	}
+
+	// Ensure that minimum returned delay is 1 hour. This is here to guard
+	// against an edge case where the delay duration got messed
+	// up as a result of maths misuse in the previous calculations
+	if delay < initialBackoffDelay {
+		delay = initialBackoffDelay
+	}
+
+	return delay
+}
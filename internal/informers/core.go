@@ -74,3 +74,28 @@ type Informer interface {
 	// one LIST has been performed)
 	HasSynced() bool
 }
+
+// CachedSecretCount returns the number of Secrets held in the caches of the
+// given Secret Informer. When Secrets are filtered, this is the total of
+// those held in the typed and metadata only caches.
+func CachedSecretCount(i Informer) int {
+	switch i := i.(type) {
+	case *informer:
+		return len(i.typedInformer.GetStore().ListKeys()) + len(i.metadataInformer.GetStore().ListKeys())
+	case cache.SharedIndexInformer:
+		return len(i.GetStore().ListKeys())
+	default:
+		return 0
+	}
+}
+
+// CachedSecret returns the Secret with the given name from the cache of the
+// given Secret Lister. Unlike Get, it never falls back to the API server: when
+// Secrets are filtered, Secrets which are only held in the metadata only cache
+// are reported as not found.
+func CachedSecret(l SecretLister, namespace, name string) (*corev1.Secret, error) {
+	if l, ok := l.(*secretLister); ok {
+		return l.typedLister.Secrets(namespace).Get(name)
+	}
+	return l.Secrets(namespace).Get(name)
+}
@@ -26,6 +26,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/clock"
 
 	acmeinstall "github.com/cert-manager/cert-manager/internal/apis/acme/install"
 	cminstall "github.com/cert-manager/cert-manager/internal/apis/certmanager/install"
@@ -33,6 +34,7 @@ import (
 	metainstall "github.com/cert-manager/cert-manager/internal/apis/meta/install"
 	"github.com/cert-manager/cert-manager/internal/plugin"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
 	"github.com/cert-manager/cert-manager/pkg/webhook/authority"
@@ -41,8 +43,6 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/webhook/server/tls"
 )
 
-var conversionHook handlers.ConversionHook = handlers.NewSchemeBackedConverter(logf.Log, Scheme)
-
 // WithConversionHandler allows you to override the handler for the `/convert`
 // endpoint in tests.
 func WithConversionHandler(handler handlers.ConversionHook) func(*server.Server) {
@@ -64,18 +64,38 @@ func NewCertManagerWebhookServer(log logr.Logger, opts config.WebhookConfigurati
 		return nil, fmt.Errorf("error creating kubernetes client: %s", err)
 	}
 
+	metricsOpts := []metrics.Option{
+		metrics.WithWebhookSlowRequestThreshold(opts.SlowRequestThreshold),
+	}
+	if opts.MetricsAuthentication.Enabled {
+		metricsOpts = append(metricsOpts, metrics.WithTokenReviewAuthentication(metrics.TokenReviewAuthentication{
+			TokenReviews: cl.AuthenticationV1().TokenReviews(),
+			HeaderName:   opts.MetricsAuthentication.TokenHeaderName,
+			Audiences:    opts.MetricsAuthentication.Audiences,
+		}))
+	}
+	webhookMetrics := metrics.NewWebhook(log, clock.RealClock{}, metricsOpts...)
+	webhookMetrics.SetLoggingVerbosity(uint32(opts.Logging.Verbosity))
+
 	// Set up the admission chain
 	admissionHandler, err := buildAdmissionChain(cl)
 	if err != nil {
 		return nil, err
 	}
+	admissionHandler.Metrics = webhookMetrics
+
+	conversionHook := handlers.NewSchemeBackedConverter(logf.Log, Scheme)
+	conversionHook.Metrics = webhookMetrics
 
 	s := &server.Server{
 		ListenAddr:        fmt.Sprintf(":%d", opts.SecurePort),
 		HealthzAddr:       fmt.Sprintf(":%d", opts.HealthzPort),
+		MetricsAddr:       opts.MetricsListenAddress,
+		Metrics:           webhookMetrics,
+		MetricsOnListener: opts.ServeMetricsOnSecurePort,
 		EnablePprof:       opts.EnablePprof,
 		PprofAddr:         opts.PprofAddress,
-		CertificateSource: buildCertificateSource(log, opts.TLSConfig, restcfg),
+		CertificateSource: buildCertificateSource(log, opts.TLSConfig, restcfg, webhookMetrics),
 		CipherSuites:      opts.TLSConfig.CipherSuites,
 		MinTLSVersion:     opts.TLSConfig.MinTLSVersion,
 		ValidationWebhook: admissionHandler,
@@ -116,13 +136,14 @@ func buildAdmissionChain(client kubernetes.Interface) (*admission.RequestHandler
 	return admission.NewRequestHandler(Scheme, pluginChain.(admission.ValidationInterface), pluginChain.(admission.MutationInterface)), nil
 }
 
-func buildCertificateSource(log logr.Logger, tlsConfig config.TLSConfig, restCfg *rest.Config) tls.CertificateSource {
+func buildCertificateSource(log logr.Logger, tlsConfig config.TLSConfig, restCfg *rest.Config, metrics *metrics.Metrics) tls.CertificateSource {
 	switch {
 	case tlsConfig.FilesystemConfigProvided():
 		log.V(logf.InfoLevel).Info("using TLS certificate from local filesystem", "private_key_path", tlsConfig.Filesystem.KeyFile, "certificate", tlsConfig.Filesystem.CertFile)
 		return &tls.FileCertificateSource{
 			CertPath: tlsConfig.Filesystem.CertFile,
 			KeyPath:  tlsConfig.Filesystem.KeyFile,
+			Metrics:  metrics,
 		}
 	case tlsConfig.DynamicConfigProvided():
 		log.V(logf.InfoLevel).Info("using dynamic certificate generating using CA stored in Secret resource", "secret_namespace", tlsConfig.Dynamic.SecretNamespace, "secret_name", tlsConfig.Dynamic.SecretName)
@@ -133,6 +154,7 @@ func buildCertificateSource(log logr.Logger, tlsConfig config.TLSConfig, restCfg
 				SecretName:      tlsConfig.Dynamic.SecretName,
 				RESTConfig:      restCfg,
 			},
+			Metrics: metrics,
 		}
 	default:
 		log.V(logf.WarnLevel).Info("serving insecurely as tls certificate data not provided")
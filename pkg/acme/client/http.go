@@ -17,7 +17,11 @@ limitations under the License.
 package client
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"strings"
 	"time"
@@ -70,7 +74,9 @@ func (it *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	start := time.Now()
 
 	// Make the request using the wrapped RoundTripper.
+	it.metrics.IncrementACMERequestsInFlight(req.URL.Host)
 	resp, err := it.wrappedRT.RoundTrip(req)
+	it.metrics.DecrementACMERequestsInFlight(req.URL.Host)
 	if resp != nil {
 		statusCode = resp.StatusCode
 	}
@@ -86,6 +92,18 @@ func (it *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	it.metrics.ObserveACMERequestDuration(time.Since(start), labels...)
 	it.metrics.IncrementACMERequestCount(labels...)
 
+	if resp != nil && isProblemDocument(resp) {
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+		// Replace the consumed body so that it can still be read by the
+		// ACME library.
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		it.metrics.IncrementACMEProblem(req.URL.Host, problemType(body))
+	}
+
 	// return the response and error reported from the next RoundTripper.
 	return resp, err
 }
@@ -100,3 +118,33 @@ func pathProcessor(path string) string {
 	}
 	return strings.Join(p, "/")
 }
+
+// acmeErrorNamespace is the prefix of the problem types defined by the ACME
+// specification, RFC 8555 section 6.7.
+const acmeErrorNamespace = "urn:ietf:params:acme:error:"
+
+// isProblemDocument returns true if the response contains a problem
+// document, as defined by RFC 7807.
+func isProblemDocument(resp *http.Response) bool {
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/problem+json"
+}
+
+// problemType returns the type of the given problem document with the ACME
+// error namespace removed, e.g. `rateLimited`. Problem types outside of the
+// ACME error namespace are reported as `other`, and problem documents which
+// cannot be decoded are reported as `unknown`.
+func problemType(body []byte) string {
+	var problem struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(body, &problem); err != nil || problem.Type == "" {
+		return "unknown"
+	}
+
+	name, ok := strings.CutPrefix(problem.Type, acmeErrorNamespace)
+	if !ok || name == "" {
+		return "other"
+	}
+	return name
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+func TestProblemType(t *testing.T) {
+	tests := map[string]struct {
+		body string
+		exp  string
+	}{
+		"ACME problem type": {
+			body: `{"type": "urn:ietf:params:acme:error:rateLimited", "detail": "too many certificates"}`,
+			exp:  "rateLimited",
+		},
+		"problem type outside of the ACME namespace": {
+			body: `{"type": "about:blank"}`,
+			exp:  "other",
+		},
+		"missing problem type": {
+			body: `{"detail": "something went wrong"}`,
+			exp:  "unknown",
+		},
+		"invalid problem document": {
+			body: `not json`,
+			exp:  "unknown",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, problemType([]byte(test.body)))
+		})
+	}
+}
+
+func TestTransportCountsProblems(t *testing.T) {
+	const problem = `{"type": "urn:ietf:params:acme:error:malformed"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(problem))
+	}))
+	defer server.Close()
+
+	m := metrics.New(logtesting.NewTestLogger(t), clock.RealClock{})
+	// Metrics are only exposed in a snapshot once registered.
+	m.Handler()
+	client := NewInstrumentedClient(m, &http.Client{})
+
+	resp, err := client.Get(server.URL + "/acme/new-order")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	// The problem document must still be readable by the caller.
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, problem, string(body))
+
+	host := server.Listener.Addr().String()
+	assert.Equal(t, 1.0, m.Snapshot()[`certmanager_acme_client_problem_count{host="`+host+`",problem_type="malformed"}`])
+}
+
+func TestTransportCountsRequestsInFlight(t *testing.T) {
+	m := metrics.New(logtesting.NewTestLogger(t), clock.RealClock{})
+	// Metrics are only exposed in a snapshot once registered.
+	m.Handler()
+
+	var host string
+	var inFlight float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inFlight = m.Snapshot()[`certmanager_acme_client_requests_in_flight{host="`+host+`"}`]
+	}))
+	defer server.Close()
+	host = server.Listener.Addr().String()
+
+	client := NewInstrumentedClient(m, &http.Client{})
+	resp, err := client.Get(server.URL + "/acme/directory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	assert.Equal(t, 1.0, inFlight, "expected the request to be in flight while it is being served")
+	assert.Equal(t, 0.0, m.Snapshot()[`certmanager_acme_client_requests_in_flight{host="`+host+`"}`])
+}
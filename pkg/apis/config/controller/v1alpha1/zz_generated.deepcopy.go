@@ -127,6 +127,32 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.EnableCertificateReadyStatusReason != nil {
+		in, out := &in.EnableCertificateReadyStatusReason, &out.EnableCertificateReadyStatusReason
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableVaultIssuanceLabels != nil {
+		in, out := &in.EnableVaultIssuanceLabels, &out.EnableVaultIssuanceLabels
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MetricsMinimumRSAKeySize != nil {
+		in, out := &in.MetricsMinimumRSAKeySize, &out.MetricsMinimumRSAKeySize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EnableMetricsZeroValuedSeries != nil {
+		in, out := &in.EnableMetricsZeroValuedSeries, &out.EnableMetricsZeroValuedSeries
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableMetricsAdminEndpoints != nil {
+		in, out := &in.EnableMetricsAdminEndpoints, &out.EnableMetricsAdminEndpoints
+		*out = new(bool)
+		**out = **in
+	}
+	in.MetricsAuthentication.DeepCopyInto(&out.MetricsAuthentication)
 	if in.EnablePprof != nil {
 		in, out := &in.EnablePprof, &out.EnablePprof
 		*out = new(bool)
@@ -226,3 +252,29 @@ func (in *LeaderElectionConfig) DeepCopy() *LeaderElectionConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsAuthenticationConfig) DeepCopyInto(out *MetricsAuthenticationConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsAuthenticationConfig.
+func (in *MetricsAuthenticationConfig) DeepCopy() *MetricsAuthenticationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsAuthenticationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
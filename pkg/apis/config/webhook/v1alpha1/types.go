@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	logsapi "k8s.io/component-base/logs/api/v1"
 )
@@ -36,6 +38,24 @@ type WebhookConfiguration struct {
 	// Defaults to 6080.
 	HealthzPort *int32 `json:"healthzPort,omitempty"`
 
+	// metricsListenAddress is the host and port that the metrics endpoint
+	// should listen on. If not specified, metrics will not be exposed.
+	MetricsListenAddress string `json:"metricsListenAddress,omitempty"`
+
+	// serveMetricsOnSecurePort serves the metrics endpoint at /metrics on the
+	// secure port, sharing the webhook's TLS listener, instead of on
+	// metricsListenAddress.
+	ServeMetricsOnSecurePort bool `json:"serveMetricsOnSecurePort,omitempty"`
+
+	// metricsAuthentication configures authentication of scrapes of the
+	// metrics endpoint.
+	MetricsAuthentication MetricsAuthenticationConfig `json:"metricsAuthentication"`
+
+	// slowRequestThreshold is the duration after which a webhook request is
+	// counted as slow in the webhook_slow_request_count metric.
+	// Defaults to 1s.
+	SlowRequestThreshold time.Duration `json:"slowRequestThreshold,omitempty"`
+
 	// tlsConfig is used to configure the secure listener's TLS settings.
 	TLSConfig TLSConfig `json:"tlsConfig"`
 
@@ -91,6 +111,24 @@ type TLSConfig struct {
 	Dynamic DynamicServingConfig `json:"dynamic"`
 }
 
+// MetricsAuthenticationConfig configures authentication of scrapes of the
+// metrics endpoint using the Kubernetes TokenReview API.
+type MetricsAuthenticationConfig struct {
+	// enabled requires scrapes of the metrics endpoint to present a bearer
+	// token, which is validated using the Kubernetes TokenReview API.
+	// Scrapes are not authenticated by default.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// tokenHeaderName is the request header which carries the bearer token,
+	// with an optional `Bearer ` prefix.
+	// Defaults to Authorization.
+	TokenHeaderName string `json:"tokenHeaderName,omitempty"`
+
+	// audiences are the audiences which the bearer token must be valid for.
+	// If not specified, the audiences of the API server are used.
+	Audiences []string `json:"audiences,omitempty"`
+}
+
 // DynamicServingConfig makes the webhook generate a CA and persist it into Secret resources.
 // This CA will be used by all instances of the webhook for signing serving certificates.
 type DynamicServingConfig struct {
@@ -18,14 +18,17 @@ package acmechallenges
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
@@ -37,6 +40,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/http"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
 type controller struct {
@@ -79,6 +83,15 @@ type controller struct {
 	// objectUpdater implements the updateObject function which is used to save
 	// changes to the Challenge.Status and Challenge.Finalizers
 	objectUpdater
+
+	clock   clock.Clock
+	metrics *metrics.Metrics
+
+	// validTimes holds the time at which each Challenge was observed to
+	// become valid, until its solver resources have been cleaned up. It is
+	// used to measure the cleanup lag, and is not persisted across restarts.
+	validTimes     map[types.UID]time.Time
+	validTimesLock sync.Mutex
 }
 
 func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
@@ -134,6 +147,9 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.scheduler = scheduler.New(logf.NewContext(ctx.RootContext, c.log), c.challengeLister, ctx.SchedulerOptions.MaxConcurrentChallenges)
 	c.recorder = ctx.Recorder
 	c.accountRegistry = ctx.ACMEOptions.AccountRegistry
+	c.clock = ctx.Clock
+	c.metrics = ctx.Metrics
+	c.validTimes = make(map[types.UID]time.Time)
 
 	var err error
 	c.httpSolver, err = http.NewSolver(ctx)
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	acmeapi "golang.org/x/crypto/acme"
 	corev1 "k8s.io/api/core/v1"
@@ -117,6 +118,7 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 
 			ch.Status.Presented = false
 		}
+		c.observeCleanupLag(ch)
 
 		ch.Status.Processing = false
 
@@ -258,6 +260,7 @@ func (c *controller) handleFinalizer(ctx context.Context, ch *cmacme.Challenge)
 	defer func() {
 		// call Update to remove the metadata.finalizers entry
 		ch.Finalizers = ch.Finalizers[1:]
+		c.forgetValidTime(ch)
 	}()
 
 	if !ch.Status.Processing {
@@ -282,6 +285,7 @@ func (c *controller) handleFinalizer(ctx context.Context, ch *cmacme.Challenge)
 		log.Error(err, "error cleaning up challenge")
 		return nil
 	}
+	c.observeCleanupLag(ch)
 
 	return nil
 }
@@ -382,11 +386,41 @@ func (c *controller) acceptChallenge(ctx context.Context, cl acmecl.Interface, c
 
 	ch.Status.State = cmacme.State(authorization.Status)
 	ch.Status.Reason = "Successfully authorized domain"
+	if ch.Status.State == cmacme.Valid {
+		c.recordValidTime(ch)
+	}
 	c.recorder.Eventf(ch, corev1.EventTypeNormal, reasonDomainVerified, "Domain %q verified with %q validation", ch.Spec.DNSName, ch.Spec.Type)
 
 	return nil
 }
 
+// recordValidTime records the current time as the time at which the
+// Challenge became valid.
+func (c *controller) recordValidTime(ch *cmacme.Challenge) {
+	c.validTimesLock.Lock()
+	defer c.validTimesLock.Unlock()
+	c.validTimes[ch.UID] = c.clock.Now()
+}
+
+// forgetValidTime removes the recorded time at which the Challenge became
+// valid, if any, returning it.
+func (c *controller) forgetValidTime(ch *cmacme.Challenge) (time.Time, bool) {
+	c.validTimesLock.Lock()
+	defer c.validTimesLock.Unlock()
+	validTime, ok := c.validTimes[ch.UID]
+	delete(c.validTimes, ch.UID)
+	return validTime, ok
+}
+
+// observeCleanupLag records the time between the Challenge becoming valid and
+// its solver resources having been cleaned up. Challenges which did not
+// become valid while this controller was running are not observed.
+func (c *controller) observeCleanupLag(ch *cmacme.Challenge) {
+	if validTime, ok := c.forgetValidTime(ch); ok {
+		c.metrics.ObserveACMEChallengeCleanupLag(string(ch.Spec.Type), c.clock.Since(validTime))
+	}
+}
+
 func (c *controller) handleAuthorizationError(ch *cmacme.Challenge, err error) error {
 	authErr, ok := err.(*acmeapi.AuthorizationError)
 	if !ok {
@@ -20,12 +20,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-logr/logr"
 	acmeapi "golang.org/x/crypto/acme"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	fakeclock "k8s.io/utils/clock/testing"
 
 	accountstest "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
@@ -34,6 +43,7 @@ import (
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
@@ -607,3 +617,46 @@ func runTest(t *testing.T, test testT) {
 
 	test.builder.CheckAndFinish(err)
 }
+
+func TestObserveCleanupLag(t *testing.T) {
+	m := metrics.New(logr.Discard(), clock.RealClock{})
+	fakeClock := fakeclock.NewFakeClock(time.Now())
+	c := &controller{
+		metrics:    m,
+		clock:      fakeClock,
+		recorder:   record.NewFakeRecorder(10),
+		validTimes: make(map[types.UID]time.Time),
+	}
+
+	ch := gen.Challenge("testchal",
+		gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+	)
+	ch.UID = "testchal-uid"
+	cl := &acmecl.FakeACME{
+		FakeAccept: func(ctx context.Context, chal *acmeapi.Challenge) (*acmeapi.Challenge, error) {
+			return &acmeapi.Challenge{Status: acmeapi.StatusPending}, nil
+		},
+		FakeWaitAuthorization: func(ctx context.Context, url string) (*acmeapi.Authorization, error) {
+			return &acmeapi.Authorization{Status: acmeapi.StatusValid}, nil
+		},
+	}
+
+	if err := c.acceptChallenge(context.Background(), cl, ch); err != nil {
+		t.Fatal(err)
+	}
+	fakeClock.Step(30 * time.Second)
+	c.observeCleanupLag(ch)
+	// The lag is only observed once per Challenge.
+	c.observeCleanupLag(ch)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, expected := range []string{
+		`certmanager_acme_challenge_cleanup_lag_seconds_sum{type="HTTP-01"} 30`,
+		`certmanager_acme_challenge_cleanup_lag_seconds_count{type="HTTP-01"} 1`,
+	} {
+		if !strings.Contains(rec.Body.String(), expected) {
+			t.Errorf("expected metrics output to contain %q, got:\n%s", expected, rec.Body.String())
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"net/url"
 	"time"
 
 	acmeapi "golang.org/x/crypto/acme"
@@ -93,7 +94,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		return nil
 	case o.Status.URL == "":
 		log.V(logf.DebugLevel).Info("Creating new ACME order as status.url is not set")
-		return c.createOrder(ctx, cl, o)
+		return c.createOrder(ctx, cl, o, genericIssuer)
 	case o.Status.FinalizeURL == "":
 		log.V(logf.DebugLevel).Info("Updating Order status as status.finalizeURL is not set")
 		_, err := c.updateOrderStatus(ctx, cl, o)
@@ -259,7 +260,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	return nil
 }
 
-func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) error {
+func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order, issuer cmapi.GenericIssuer) error {
 	log := logf.FromContext(ctx)
 
 	if o.Status.URL != "" {
@@ -284,7 +285,9 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 	if o.Spec.Duration != nil {
 		options = append(options, acmeapi.WithOrderNotAfter(c.clock.Now().Add(o.Spec.Duration.Duration)))
 	}
+	start := c.clock.Now()
 	acmeOrder, err := cl.AuthorizeOrder(ctx, authzIDs, options...)
+	c.metrics.ObserveACMENewOrderDuration(acmeServerHost(issuer), c.clock.Since(start))
 	if acmeErr, ok := err.(*acmeapi.Error); ok {
 		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
 			log.Error(err, "failed to create Order resource due to bad request, marking Order as failed")
@@ -306,6 +309,20 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 	return nil
 }
 
+// acmeServerHost returns the host of the ACME server used by the issuer, or
+// an empty string if it cannot be determined.
+func acmeServerHost(issuer cmapi.GenericIssuer) string {
+	acmeSpec := issuer.GetSpec().ACME
+	if acmeSpec == nil {
+		return ""
+	}
+	u, err := url.Parse(acmeSpec.Server)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
 func (c *controller) updateOrderStatus(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) (*acmeapi.Order, error) {
 	acmeOrder, err := getACMEOrder(ctx, cl, o)
 	if err != nil {
@@ -390,6 +407,9 @@ func (c *controller) fetchMetadataForAuthorizations(ctx context.Context, o *cmac
 		}
 
 		authz.InitialState = cmacme.State(acmeAuthz.Status)
+		if authz.InitialState == cmacme.Valid {
+			c.metrics.IncrementACMEAuthorizationReused(o.Spec.IssuerRef.Name, o.Namespace)
+		}
 		authz.Identifier = acmeAuthz.Identifier.Value
 		authz.Wildcard = &acmeAuthz.Wildcard
 		authz.Challenges = make([]cmacme.ACMEChallenge, len(acmeAuthz.Challenges))
@@ -527,6 +547,7 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 	}
 
 	// Call to CreateOrderCert finalizes the ACME order. This call can only be made once.
+	start := c.clock.Now()
 	certSlice, certURL, err := cl.CreateOrderCert(ctx, o.Status.FinalizeURL, derBytes, true)
 
 	acmeErr, ok := err.(*acmeapi.Error)
@@ -591,13 +612,18 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 			return fmt.Errorf("error retrieving alternate chain: %w", err)
 		}
 		if found {
-			return c.storeCertificateOnStatus(ctx, o, altChain)
+			certSlice = altChain
+		} else {
+			// if no match is found we return to the actual cert
+			// it is a *preferred* chain after all
+			log.V(logf.DebugLevel).Info(fmt.Sprintf("Preferred chain %s not found, fall back to the default cert", preferredChain))
 		}
-		// if no match is found we return to the actual cert
-		// it is a *preferred* chain after all
-		log.V(logf.DebugLevel).Info(fmt.Sprintf("Preferred chain %s not found, fall back to the default cert", preferredChain))
 	}
 
+	// Only successful finalizations are observed, including the time taken
+	// to download the certificate.
+	c.metrics.ObserveACMEOrderFinalizeDuration(acmeServerHost(issuer), c.clock.Since(start))
+
 	return c.storeCertificateOnStatus(ctx, o, certSlice)
 }
 
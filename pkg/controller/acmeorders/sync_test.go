@@ -21,13 +21,19 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr"
 	acmeapi "golang.org/x/crypto/acme"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	fakeclock "k8s.io/utils/clock/testing"
 
 	accountstest "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
@@ -35,6 +41,7 @@ import (
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	schedulertest "github.com/cert-manager/cert-manager/pkg/scheduler/test"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
@@ -841,3 +848,107 @@ func runTest(t *testing.T, test testT) {
 
 	test.builder.CheckAndFinish(err)
 }
+
+func TestFetchMetadataForAuthorizationsCountsReused(t *testing.T) {
+	m := metrics.New(logr.Discard(), clock.RealClock{})
+	// Metrics are only exposed in a snapshot once registered.
+	m.Handler()
+	c := &controller{metrics: m}
+
+	order := gen.Order("testorder",
+		gen.SetOrderNamespace("default"),
+		gen.SetOrderIssuer(cmmeta.ObjectReference{Name: "testissuer"}),
+		gen.SetOrderStatus(cmacme.OrderStatus{
+			Authorizations: []cmacme.ACMEAuthorization{
+				{URL: "http://authzurl/valid"},
+				{URL: "http://authzurl/pending"},
+				// Metadata is only fetched once, so authorizations which
+				// have already been seen are not counted again.
+				{URL: "http://authzurl/fetched", Identifier: "fetched.com", InitialState: cmacme.Valid},
+			},
+		}),
+	)
+	cl := &acmecl.FakeACME{
+		FakeGetAuthorization: func(ctx context.Context, url string) (*acmeapi.Authorization, error) {
+			status := acmeapi.StatusPending
+			if url == "http://authzurl/valid" {
+				status = acmeapi.StatusValid
+			}
+			return &acmeapi.Authorization{URI: url, Status: status, Identifier: acmeapi.AuthzID{Type: "dns", Value: "test.com"}}, nil
+		},
+	}
+
+	if err := c.fetchMetadataForAuthorizations(context.Background(), order, cl); err != nil {
+		t.Fatal(err)
+	}
+
+	key := `certmanager_acme_authorization_reused_count{issuer_name="testissuer",namespace="default"}`
+	if v := m.Snapshot()[key]; v != 1 {
+		t.Errorf("expected %s to be 1, got %v", key, v)
+	}
+}
+
+func TestCreateOrderObservesNewOrderDuration(t *testing.T) {
+	m := metrics.New(logr.Discard(), clock.RealClock{})
+	c := &controller{metrics: m, clock: fakeclock.NewFakeClock(time.Now())}
+
+	issuer := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{
+		Server: "https://acme.example.com/directory",
+	}))
+	order := gen.Order("testorder", gen.SetOrderDNSNames("test.com"))
+	cl := &acmecl.FakeACME{
+		FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+			return &acmeapi.Order{URI: "http://testurl.com/abcde", Status: acmeapi.StatusPending}, nil
+		},
+	}
+
+	if err := c.createOrder(context.Background(), cl, order, issuer); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	expected := `certmanager_acme_new_order_duration_seconds_count{host="acme.example.com"} 1`
+	if !strings.Contains(rec.Body.String(), expected) {
+		t.Errorf("expected metrics output to contain %q, got:\n%s", expected, rec.Body.String())
+	}
+}
+
+func TestFinalizeOrderObservesFinalizeDuration(t *testing.T) {
+	m := metrics.New(logr.Discard(), clock.RealClock{})
+	fakeClock := fakeclock.NewFakeClock(time.Now())
+	c := &controller{metrics: m, clock: fakeClock, recorder: record.NewFakeRecorder(1)}
+
+	issuer := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{
+		Server: "https://acme.example.com/directory",
+	}))
+	order := gen.Order("testorder",
+		gen.SetOrderDNSNames("test.com"),
+		gen.SetOrderURL("http://testurl.com/abcde"),
+		gen.SetOrderState(cmacme.Ready),
+	)
+	cl := &acmecl.FakeACME{
+		FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
+			fakeClock.Step(3 * time.Second)
+			return [][]byte{[]byte("test")}, "http://testurl.com/cert", nil
+		},
+		FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+			return &acmeapi.Order{URI: url, Status: acmeapi.StatusValid}, nil
+		},
+	}
+
+	if err := c.finalizeOrder(context.Background(), cl, order, issuer); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, expected := range []string{
+		`certmanager_acme_order_finalize_duration_seconds_sum{host="acme.example.com"} 3`,
+		`certmanager_acme_order_finalize_duration_seconds_count{host="acme.example.com"} 1`,
+	} {
+		if !strings.Contains(rec.Body.String(), expected) {
+			t.Errorf("expected metrics output to contain %q, got:\n%s", expected, rec.Body.String())
+		}
+	}
+}
@@ -26,14 +26,22 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
 const (
 	ControllerName = "certificaterequests-approver"
+
+	// manualApprovalReason is the condition reason set by default when a
+	// CertificateRequest is approved or denied using cmctl.
+	manualApprovalReason = "KubectlCertManager"
 )
 
 // Controller is a CertificateRequest controller which manages the "Approved"
@@ -50,6 +58,7 @@ type Controller struct {
 	fieldManager             string
 
 	recorder record.EventRecorder
+	metrics  *metrics.Metrics
 
 	queue workqueue.RateLimitingInterface
 }
@@ -72,11 +81,15 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
 	mustSync := []cache.InformerSynced{certificateRequestInformer.Informer().HasSynced}
 	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+	certificateRequestInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: c.observeDecision,
+	})
 
 	c.certificateRequestLister = certificateRequestInformer.Lister()
 	c.cmClient = ctx.CMClient
 	c.fieldManager = ctx.FieldManager
 	c.recorder = ctx.Recorder
+	c.metrics = ctx.Metrics
 
 	c.log.V(logf.DebugLevel).Info("certificate request approver controller registered")
 
@@ -106,3 +119,51 @@ func (c *Controller) ProcessItem(ctx context.Context, key string) error {
 	ctx = logf.NewContext(ctx, logf.WithResource(log, cr))
 	return c.Sync(ctx, cr)
 }
+
+// observeDecision counts the approval decisions made for a CertificateRequest
+// by approvers other than this controller, when its "Approved" or "Denied"
+// condition is first set to True. Approvals made by this controller are
+// counted when they are made in Sync.
+func (c *Controller) observeDecision(oldObj, newObj interface{}) {
+	oldCR, ok := oldObj.(*cmapi.CertificateRequest)
+	if !ok {
+		return
+	}
+	newCR, ok := newObj.(*cmapi.CertificateRequest)
+	if !ok {
+		return
+	}
+
+	for condType, decision := range map[cmapi.CertificateRequestConditionType]string{
+		cmapi.CertificateRequestConditionApproved: metrics.PolicyDecisionAllowed,
+		cmapi.CertificateRequestConditionDenied:   metrics.PolicyDecisionDenied,
+	} {
+		if cond := apiutil.GetCertificateRequestCondition(oldCR, condType); cond != nil && cond.Status == cmmeta.ConditionTrue {
+			continue
+		}
+		cond := apiutil.GetCertificateRequestCondition(newCR, condType)
+		if cond == nil || cond.Status != cmmeta.ConditionTrue {
+			continue
+		}
+
+		source := approvalSource(cond.Reason)
+		if source == metrics.ApprovalSourceInternal {
+			continue
+		}
+		c.metrics.IncrementCertificateRequestPolicyDecision(cond.Reason, decision, source)
+	}
+}
+
+// approvalSource returns the approval_source label for a decision made with
+// the given condition reason. Decisions which were not made by this
+// controller or cmctl are assumed to have been made by a policy approver.
+func approvalSource(reason string) string {
+	switch reason {
+	case "cert-manager.io":
+		return metrics.ApprovalSourceInternal
+	case manualApprovalReason:
+		return metrics.ApprovalSourceManual
+	default:
+		return metrics.ApprovalSourcePolicy
+	}
+}
@@ -18,17 +18,22 @@ package approver
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	coretesting "k8s.io/client-go/testing"
+	"k8s.io/utils/clock"
 	fakeclock "k8s.io/utils/clock/testing"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
 func TestProcessItem(t *testing.T) {
@@ -238,3 +243,64 @@ func TestProcessItem(t *testing.T) {
 		})
 	}
 }
+
+func TestObserveDecision(t *testing.T) {
+	withCondition := func(condType cmapi.CertificateRequestConditionType, reason string) *cmapi.CertificateRequest {
+		return &cmapi.CertificateRequest{
+			Status: cmapi.CertificateRequestStatus{
+				Conditions: []cmapi.CertificateRequestCondition{
+					{Type: condType, Status: cmmeta.ConditionTrue, Reason: reason},
+				},
+			},
+		}
+	}
+	pending := &cmapi.CertificateRequest{}
+
+	tests := map[string]struct {
+		oldCR, newCR *cmapi.CertificateRequest
+		expSnapshot  map[string]float64
+	}{
+		"approval by this controller is not counted": {
+			oldCR:       pending,
+			newCR:       withCondition(cmapi.CertificateRequestConditionApproved, "cert-manager.io"),
+			expSnapshot: map[string]float64{},
+		},
+		"approval by a policy approver is counted as policy": {
+			oldCR: pending,
+			newCR: withCondition(cmapi.CertificateRequestConditionApproved, "policy.cert-manager.io"),
+			expSnapshot: map[string]float64{
+				`certmanager_certificaterequest_policy_decision_count{approval_source="policy",decision="allowed",policy="policy.cert-manager.io"}`: 1,
+			},
+		},
+		"denial using cmctl is counted as manual": {
+			oldCR: pending,
+			newCR: withCondition(cmapi.CertificateRequestConditionDenied, manualApprovalReason),
+			expSnapshot: map[string]float64{
+				`certmanager_certificaterequest_policy_decision_count{approval_source="manual",decision="denied",policy="KubectlCertManager"}`: 1,
+			},
+		},
+		"an existing decision is not counted again": {
+			oldCR:       withCondition(cmapi.CertificateRequestConditionApproved, manualApprovalReason),
+			newCR:       withCondition(cmapi.CertificateRequestConditionApproved, manualApprovalReason),
+			expSnapshot: map[string]float64{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := metrics.New(logtesting.NewTestLogger(t), clock.RealClock{})
+			m.Handler()
+
+			c := &Controller{metrics: m}
+			c.observeDecision(test.oldCR, test.newCR)
+
+			snapshot := map[string]float64{}
+			for key, value := range m.Snapshot() {
+				if strings.HasPrefix(key, "certmanager_certificaterequest_policy_decision_count") {
+					snapshot[key] = value
+				}
+			}
+			assert.Equal(t, test.expSnapshot, snapshot)
+		})
+	}
+}
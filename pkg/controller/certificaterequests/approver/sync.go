@@ -28,6 +28,7 @@ import (
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
 
@@ -69,6 +70,7 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		return err
 	}
 	c.recorder.Event(cr, corev1.EventTypeNormal, "cert-manager.io", ApprovedMessage)
+	c.metrics.IncrementCertificateRequestPolicyDecision("cert-manager.io", metrics.PolicyDecisionAllowed, metrics.ApprovalSourceInternal)
 
 	log.V(logf.DebugLevel).Info("approved certificate request")
 
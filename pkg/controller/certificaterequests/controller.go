@@ -35,6 +35,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
 var keyFunc = controllerpkg.KeyFunc
@@ -99,6 +100,8 @@ type Controller struct {
 	// used for testing
 	clock clock.Clock
 
+	metrics *metrics.Metrics
+
 	reporter *util.Reporter
 }
 
@@ -184,6 +187,7 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.reporter = util.NewReporter(c.clock, c.recorder)
 	c.cmClient = ctx.CMClient
 	c.fieldManager = ctx.FieldManager
+	c.metrics = ctx.Metrics
 
 	// Construct the issuer implementation with the built component context.
 	c.issuer = c.issuerConstructor(ctx)
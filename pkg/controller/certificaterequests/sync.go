@@ -35,6 +35,7 @@ import (
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
@@ -165,6 +166,13 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	// Set condition to Ready.
 	c.reporter.Ready(crCopy)
 
+	// Certificates issued for a Certificate are counted once they have been
+	// stored in its Secret, so only CertificateRequests with a CSR provided
+	// by a client are counted here.
+	if _, ok := crCopy.Annotations[cmapi.CertificateNameKey]; !ok {
+		c.metrics.IncrementCertificateIssued(crCopy.Spec.IssuerRef, metrics.CSRSourceClient)
+	}
+
 	return nil
 }
 
@@ -24,9 +24,11 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
@@ -151,6 +153,12 @@ func TestSync(t *testing.T) {
 		}),
 	)
 
+	baseCROwned := gen.CertificateRequestFrom(baseCR,
+		gen.AddCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateNameKey: "test-cert",
+		}),
+	)
+
 	certRSAPEM := generateSelfSignedCert(t, baseCR, skRSA, fixedClockStart, fixedClockStart.Add(time.Hour*12))
 	certRSAPEMExpired := generateSelfSignedCert(t, baseCR, skRSA, fixedClockStart.Add(-time.Hour*13), fixedClockStart.Add(-time.Hour*12))
 
@@ -639,6 +647,39 @@ func TestSync(t *testing.T) {
 				},
 			},
 		},
+		"if calling sign returns a response with a valid RSA signed certificate for a CertificateRequest owned by a Certificate then set condition Ready": {
+			certificateRequest: baseCROwned.DeepCopy(),
+			issuerImpl: &fake.Issuer{
+				FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+					return &issuer.IssueResponse{
+						Certificate: certRSAPEM,
+					}, nil
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer, baseCROwned.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCROwned,
+							gen.SetCertificateRequestCertificate(certRSAPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "Issued",
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+				},
+			},
+		},
 		"if calling sign returns a response with an expired RSA certificate then set condition Ready": {
 			certificateRequest: baseCR.DeepCopy(),
 			issuerImpl: &fake.Issuer{
@@ -779,6 +820,8 @@ func runTest(t *testing.T, test testT) {
 	}
 
 	test.builder.Start()
+	// Metrics are only exposed in a snapshot once registered.
+	test.builder.Metrics.Handler()
 
 	err := c.Sync(context.Background(), test.certificateRequest)
 	if err != nil && !test.expectedErr {
@@ -787,5 +830,19 @@ func runTest(t *testing.T, test testT) {
 	if err == nil && test.expectedErr {
 		t.Errorf("expected to get an error but did not get one")
 	}
+
+	// Certificates issued for CertificateRequests which are not owned by a
+	// Certificate should be counted as having a client-provided CSR.
+	expIssued := 0.0
+	if _, ok := test.certificateRequest.Annotations[cmapi.CertificateNameKey]; !ok {
+		for _, event := range test.builder.ExpectedEvents {
+			if event == "Normal CertificateIssued Certificate fetched from issuer successfully" {
+				expIssued++
+			}
+		}
+	}
+	ref := test.certificateRequest.Spec.IssuerRef
+	assert.Equal(t, expIssued, test.builder.Metrics.Snapshot()[fmt.Sprintf(
+		`certmanager_certificate_issued_count{csr_source="client",issuer_group=%q,issuer_kind=%q}`, ref.Group, ref.Kind)])
 	test.builder.CheckAndFinish(err)
 }
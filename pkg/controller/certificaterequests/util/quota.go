@@ -0,0 +1,51 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"strings"
+)
+
+// quotaExceededMessages are the phrases used by CAs to report that a quota
+// has been exceeded, e.g. Vault's "lease count quota exceeded".
+var quotaExceededMessages = []string{
+	"quota exceeded",
+	"exceeded quota",
+	"quota has been exceeded",
+}
+
+// IsQuotaExceededError returns true if the given error, returned by a CA,
+// reports that a quota has been exceeded. The issuer clients do not return
+// typed errors, so the error message is matched. Rate limits, such as
+// Vault's "rate limit quota exceeded", are transient and are not treated as
+// exceeded quotas.
+func IsQuotaExceededError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	message := strings.ToLower(err.Error())
+	if strings.Contains(message, "rate limit") {
+		return false
+	}
+	for _, m := range quotaExceededMessages {
+		if strings.Contains(message, m) {
+			return true
+		}
+	}
+	return false
+}
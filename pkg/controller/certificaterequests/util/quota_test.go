@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsQuotaExceededError(t *testing.T) {
+	tests := map[string]struct {
+		err error
+		exp bool
+	}{
+		"nil error": {
+			err: nil,
+			exp: false,
+		},
+		"unrelated error": {
+			err: errors.New("connection refused"),
+			exp: false,
+		},
+		"vault lease count quota": {
+			err: errors.New(`failed to sign certificate by vault: Error making API request. Code: 429. Errors: * 1 error occurred: * lease count quota exceeded`),
+			exp: true,
+		},
+		"quota message in a different case": {
+			err: errors.New("Certificate Quota Has Been Exceeded for this zone"),
+			exp: true,
+		},
+		"vault rate limit quota is a rate limit": {
+			err: errors.New(`request path "pki/sign/example": rate limit quota exceeded`),
+			exp: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IsQuotaExceededError(test.err); got != test.exp {
+				t.Errorf("expected %t, got %t", test.exp, got)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package vault
 
 import (
 	"context"
+	"path"
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 
@@ -30,6 +31,7 @@ import (
 	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
 const (
@@ -44,6 +46,7 @@ type Vault struct {
 	createTokenFn func(ns string) vaultinternal.CreateToken
 	secretsLister internalinformers.SecretLister
 	reporter      *crutil.Reporter
+	metrics       *metrics.Metrics
 
 	vaultClientBuilder vaultinternal.ClientBuilder
 }
@@ -66,6 +69,7 @@ func NewVault(ctx *controllerpkg.Context) certificaterequests.Issuer {
 		},
 		secretsLister:      ctx.KubeSharedInformerFactory.Secrets().Lister(),
 		reporter:           crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		metrics:            ctx.Metrics,
 		vaultClientBuilder: vaultinternal.New,
 	}
 }
@@ -95,9 +99,19 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 		return nil, nil
 	}
 
+	// The PKI role is the final element of the sign path, e.g.
+	// `pki_int/sign/example-dot-com`.
+	vaultPath := issuerObj.GetSpec().Vault.Path
+	vaultRole := path.Base(vaultPath)
+
 	certDuration := apiutil.DefaultCertDuration(cr.Spec.Duration)
 	certPem, caPem, err := client.Sign(cr.Spec.Request, certDuration)
 	if err != nil {
+		v.metrics.IncrementVaultIssuance(vaultRole, vaultPath, metrics.VaultIssuanceResultError)
+		if crutil.IsQuotaExceededError(err) {
+			v.metrics.IncrementIssuerQuotaExceeded(cr.Spec.IssuerRef)
+		}
+
 		message := "Vault failed to sign certificate"
 
 		v.reporter.Failed(cr, err, "SigningError", message)
@@ -106,6 +120,8 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 		return nil, nil
 	}
 
+	v.metrics.IncrementVaultIssuance(vaultRole, vaultPath, metrics.VaultIssuanceResultSuccess)
+
 	log.V(logf.DebugLevel).Info("certificate issued")
 
 	return &issuer.IssueResponse{
@@ -129,6 +129,10 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 				return nil, nil
 
 			default:
+				if crutil.IsQuotaExceededError(err) {
+					v.metrics.IncrementIssuerQuotaExceeded(cr.Spec.IssuerRef)
+				}
+
 				message := "Failed to request venafi certificate"
 
 				v.reporter.Failed(cr, err, "RequestError", message)
@@ -156,6 +160,10 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 			return nil, err
 
 		default:
+			if crutil.IsQuotaExceededError(err) {
+				v.metrics.IncrementIssuerQuotaExceeded(cr.Spec.IssuerRef)
+			}
+
 			message := "Failed to obtain venafi certificate"
 
 			v.reporter.Failed(cr, err, "RetrieveError", message)
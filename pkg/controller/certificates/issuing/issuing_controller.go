@@ -17,6 +17,7 @@ limitations under the License.
 package issuing
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"fmt"
@@ -45,6 +46,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	utilkube "github.com/cert-manager/cert-manager/pkg/util/kube"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -86,6 +88,10 @@ type controller struct {
 
 	// localTemporarySigner signs a certificate that is stored temporarily
 	localTemporarySigner localTemporarySignerFn
+
+	// metrics is used to count successful issuances, and errors encountered
+	// while reconciling Certificates.
+	metrics *metrics.Metrics
 }
 
 func NewController(
@@ -144,10 +150,11 @@ func NewController(
 		),
 		fieldManager:         ctx.FieldManager,
 		localTemporarySigner: pki.GenerateLocallySignedTemporaryCertificate,
+		metrics:              ctx.Metrics,
 	}, queue, mustSync
 }
 
-func (c *controller) ProcessItem(ctx context.Context, key string) error {
+func (c *controller) ProcessItem(ctx context.Context, key string) (err error) {
 	// TODO: Change to globals.DefaultControllerContextTimeout as part of a wider effort to ensure we have
 	// failsafe timeouts in every controller
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
@@ -172,6 +179,12 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	log = logf.WithResource(log, crt)
 	ctx = logf.NewContext(ctx, log)
 
+	defer func() {
+		if err != nil {
+			c.metrics.IncrementCertificateReconcileError(crt, err)
+		}
+	}()
+
 	if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
 		Type:   cmapi.CertificateConditionIssuing,
 		Status: cmmeta.ConditionTrue,
@@ -389,6 +402,12 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 	if err != nil {
 		return err
 	}
+	// Compare against the certificate issued for the previous revision
+	// rather than the one stored in the Secret, which already holds the new
+	// certificate if this issuance is being retried.
+	identical := c.matchesPreviousRevision(crt, nextRevision, req.Status.Certificate)
+	stored := c.storedCertificate(crt)
+
 	secretData := internal.SecretData{
 		PrivateKey:      pkData,
 		Certificate:     req.Status.Certificate,
@@ -403,6 +422,18 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 		return err
 	}
 
+	// Count the issuance where the Secret is written, and only if the write
+	// changed its certificate, so that an issuance which is retried after the
+	// Secret was written is not counted again. An issuer returning the
+	// previous revision's certificate leaves the Secret unchanged, but is
+	// still counted.
+	if identical || !bytes.Equal(stored, req.Status.Certificate) {
+		c.metrics.IncrementCertificateIssued(crt.Spec.IssuerRef, metrics.CSRSourceController)
+		if identical {
+			c.metrics.IncrementCertificateRenewalIdentical(crt.Spec.IssuerRef.Kind)
+		}
+	}
+
 	//Set status.revision to revision of the CertificateRequest
 	crt.Status.Revision = &nextRevision
 
@@ -428,6 +459,43 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 
 }
 
+// storedCertificate returns the certificate data stored in the Certificate's
+// Secret, or nil if the Secret does not exist.
+func (c *controller) storedCertificate(crt *cmapi.Certificate) []byte {
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if err != nil {
+		return nil
+	}
+
+	return secret.Data[corev1.TLSCertKey]
+}
+
+// matchesPreviousRevision returns true if the leaf certificate in the given
+// PEM data is identical to the one issued for the revision before
+// nextRevision. It returns false if there is no CertificateRequest for the
+// previous revision, or if either certificate cannot be decoded.
+func (c *controller) matchesPreviousRevision(crt *cmapi.Certificate, nextRevision int, certData []byte) bool {
+	reqs, err := certificates.ListCertificateRequestsMatchingPredicates(c.certificateRequestLister.CertificateRequests(crt.Namespace),
+		labels.Everything(),
+		predicate.CertificateRequestRevision(nextRevision-1),
+		predicate.ResourceOwnedBy(crt),
+	)
+	if err != nil || len(reqs) != 1 {
+		return false
+	}
+
+	previous, err := utilpki.DecodeX509CertificateBytes(reqs[0].Status.Certificate)
+	if err != nil {
+		return false
+	}
+	issued, err := utilpki.DecodeX509CertificateBytes(certData)
+	if err != nil {
+		return false
+	}
+
+	return previous.Equal(issued)
+}
+
 // updateOrApplyStatus will update the controller status. If the
 // ServerSideApply feature is enabled, the managed fields will instead get
 // applied using the relevant Patch API call.
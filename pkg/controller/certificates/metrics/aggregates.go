@@ -0,0 +1,190 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/component-base/featuregate"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+// This file contains the aggregate metrics which depend on the behaviour of
+// the controllers, so are computed here rather than by the metrics package.
+
+// certificatesInBackoff returns the Certificates which are still within the
+// backoff period following their last failed issuance attempt. Certificates
+// whose spec changed since the failure are retried immediately by the
+// trigger controller, but are still returned.
+func certificatesInBackoff(now time.Time, crts []*cmapi.Certificate) []*cmapi.Certificate {
+	var inBackoff []*cmapi.Certificate
+	for _, crt := range crts {
+		if crt.Status.LastFailureTime == nil {
+			continue
+		}
+		if now.Sub(crt.Status.LastFailureTime.Time) < internalcertificates.IssuanceBackoffDelay(crt) {
+			inBackoff = append(inBackoff, crt)
+		}
+	}
+	return inBackoff
+}
+
+// gatedCertificateFeatures are the controller feature gates which must be
+// enabled for a field of a Certificate's spec to take effect, along with a
+// function reporting whether a Certificate sets that field.
+var gatedCertificateFeatures = []struct {
+	feature featuregate.Feature
+	uses    func(*cmapi.Certificate) bool
+}{
+	{
+		feature: feature.AdditionalCertificateOutputFormats,
+		uses:    func(crt *cmapi.Certificate) bool { return len(crt.Spec.AdditionalOutputFormats) > 0 },
+	},
+	{
+		feature: feature.LiteralCertificateSubject,
+		uses:    func(crt *cmapi.Certificate) bool { return crt.Spec.LiteralSubject != "" },
+	},
+}
+
+// gatedFeatureBlockedCounts counts the Certificates which set a field that
+// the controller ignores because its feature gate is disabled, by feature
+// gate. All gated features are counted, with a count of zero when the gate is
+// enabled.
+func gatedFeatureBlockedCounts(crts []*cmapi.Certificate) map[string]int {
+	counts := make(map[string]int, len(gatedCertificateFeatures))
+	for _, gated := range gatedCertificateFeatures {
+		var blocked int
+		if !utilfeature.DefaultFeatureGate.Enabled(gated.feature) {
+			for _, crt := range crts {
+				if gated.uses(crt) {
+					blocked++
+				}
+			}
+		}
+		counts[string(gated.feature)] = blocked
+	}
+	return counts
+}
+
+// shimAnnotationConflictCounts counts the TLS entries of annotated Ingresses
+// for which a Certificate already exists that is not owned by the Ingress, by
+// namespace. ingress-shim refuses to update such Certificates, so the
+// annotations on the Ingress are silently ignored.
+func shimAnnotationConflictCounts(crts []*cmapi.Certificate, ingresses []*networkingv1.Ingress) map[string]int {
+	counts := make(map[string]int)
+
+	crtsByName := make(map[types.NamespacedName]*cmapi.Certificate, len(crts))
+	for _, crt := range crts {
+		crtsByName[types.NamespacedName{Namespace: crt.Namespace, Name: crt.Name}] = crt
+	}
+
+	for _, ing := range ingresses {
+		if !hasShimAnnotation(ing) {
+			continue
+		}
+
+		for _, tls := range ing.Spec.TLS {
+			if tls.SecretName == "" {
+				continue
+			}
+
+			// ingress-shim names Certificates after the Secret they are
+			// stored in.
+			crt, ok := crtsByName[types.NamespacedName{Namespace: ing.Namespace, Name: tls.SecretName}]
+			if !ok || metav1.IsControlledBy(crt, ing) {
+				continue
+			}
+
+			counts[ing.Namespace]++
+		}
+	}
+
+	return counts
+}
+
+// shimMissingCertificateCounts counts the Certificates which annotated
+// Ingresses and Gateways request but which do not exist, by the namespace
+// they are expected in. Only the TLS entries and listeners which ingress-shim
+// would create a Certificate for are counted, and a Certificate requested by
+// several resources is counted once.
+func shimMissingCertificateCounts(crts []*cmapi.Certificate, ingresses []*networkingv1.Ingress, gateways []*gwapi.Gateway) map[string]int {
+	existing := make(map[types.NamespacedName]struct{}, len(crts))
+	for _, crt := range crts {
+		existing[types.NamespacedName{Namespace: crt.Namespace, Name: crt.Name}] = struct{}{}
+	}
+
+	missing := make(map[types.NamespacedName]struct{})
+	expect := func(namespace, name string) {
+		key := types.NamespacedName{Namespace: namespace, Name: name}
+		if _, ok := existing[key]; !ok {
+			missing[key] = struct{}{}
+		}
+	}
+
+	for _, ing := range ingresses {
+		if !hasShimAnnotation(ing) {
+			continue
+		}
+		for _, tls := range ing.Spec.TLS {
+			if tls.SecretName == "" || len(tls.Hosts) == 0 {
+				continue
+			}
+			// ingress-shim names Certificates after the Secret they are
+			// stored in.
+			expect(ing.Namespace, tls.SecretName)
+		}
+	}
+
+	for _, gw := range gateways {
+		if !hasShimAnnotation(gw) {
+			continue
+		}
+		for _, l := range gw.Spec.Listeners {
+			if l.Hostname == nil || *l.Hostname == "" || l.TLS == nil || l.TLS.Mode == nil || *l.TLS.Mode != gwapi.TLSModeTerminate {
+				continue
+			}
+			for _, ref := range l.TLS.CertificateRefs {
+				// ingress-shim refuses cross-namespace references.
+				if ref.Namespace != nil && string(*ref.Namespace) != gw.Namespace {
+					continue
+				}
+				expect(gw.Namespace, string(ref.Name))
+			}
+		}
+	}
+
+	counts := make(map[string]int)
+	for key := range missing {
+		counts[key.Namespace]++
+	}
+	return counts
+}
+
+// hasShimAnnotation returns true if the given Ingress or Gateway has one of
+// the annotations which ingress-shim creates Certificates for.
+func hasShimAnnotation(obj metav1.Object) bool {
+	annotations := obj.GetAnnotations()
+	return annotations[cmapi.IngressIssuerNameAnnotationKey] != "" || annotations[cmapi.IngressClusterIssuerNameAnnotationKey] != ""
+}
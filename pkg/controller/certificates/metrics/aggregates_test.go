@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCertificatesInBackoff(t *testing.T) {
+	now := time.Unix(1000000, 0)
+
+	crtFailedAgo := func(name string, attempts int, d time.Duration) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: "Issuer", Group: "cert-manager.io"}),
+			gen.SetCertificateLastFailureTime(metav1.NewTime(now.Add(-d))),
+			gen.SetCertificateIssuanceAttempts(&attempts),
+		)
+	}
+
+	// The first failure backs off for 1h.
+	crt1 := crtFailedAgo("crt1", 1, 30*time.Minute)
+	crt2 := crtFailedAgo("crt2", 1, 2*time.Hour)
+	// The third failure backs off for 4h.
+	crt3 := crtFailedAgo("crt3", 3, 2*time.Hour)
+	// Certificates which have not failed are not backing off.
+	crt4 := gen.Certificate("crt4", gen.SetCertificateNamespace("test-ns"))
+
+	assert.Equal(t, []*cmapi.Certificate{crt1, crt3}, certificatesInBackoff(now, []*cmapi.Certificate{crt1, crt2, crt3, crt4}))
+}
+
+func TestGatedFeatureBlockedCounts(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, feature.AdditionalCertificateOutputFormats, true)()
+	defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, feature.LiteralCertificateSubject, false)()
+
+	withOutputFormats := gen.Certificate("crt2", gen.SetCertificateAdditionalOutputFormats(cmapi.CertificateAdditionalOutputFormat{Type: "DER"}))
+	withLiteralSubject := gen.Certificate("crt3")
+	withLiteralSubject.Spec.LiteralSubject = "CN=example.com"
+	withBoth := gen.Certificate("crt4", gen.SetCertificateAdditionalOutputFormats(cmapi.CertificateAdditionalOutputFormat{Type: "CombinedPEM"}))
+	withBoth.Spec.LiteralSubject = "CN=example.com"
+
+	// Certificates using a feature whose gate is enabled are not blocked.
+	assert.Equal(t, map[string]int{
+		"AdditionalCertificateOutputFormats": 0,
+		"LiteralCertificateSubject":          2,
+	}, gatedFeatureBlockedCounts([]*cmapi.Certificate{
+		gen.Certificate("crt1"),
+		withOutputFormats,
+		withLiteralSubject,
+		withBoth,
+	}))
+}
+
+func TestShimAnnotationConflictCounts(t *testing.T) {
+	ingress := func(name, namespace string, annotations map[string]string, secretNames ...string) *networkingv1.Ingress {
+		ing := &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespace,
+				UID:         types.UID("uid-" + name),
+				Annotations: annotations,
+			},
+		}
+		for _, secretName := range secretNames {
+			ing.Spec.TLS = append(ing.Spec.TLS, networkingv1.IngressTLS{SecretName: secretName})
+		}
+		return ing
+	}
+	issuerAnnotation := map[string]string{cmapi.IngressIssuerNameAnnotationKey: "test-issuer"}
+
+	owned := ingress("owned", "ns1", issuerAnnotation, "owned-tls")
+	ownedCrt := gen.Certificate("owned-tls", gen.SetCertificateNamespace("ns1"))
+	ownedCrt.OwnerReferences = []metav1.OwnerReference{
+		*metav1.NewControllerRef(owned, networkingv1.SchemeGroupVersion.WithKind("Ingress")),
+	}
+	crts := []*cmapi.Certificate{
+		ownedCrt,
+		gen.Certificate("manual-tls", gen.SetCertificateNamespace("ns1")),
+		gen.Certificate("other-tls", gen.SetCertificateNamespace("ns2")),
+	}
+
+	assert.Equal(t, map[string]int{"ns1": 1, "ns2": 1}, shimAnnotationConflictCounts(crts, []*networkingv1.Ingress{
+		owned,
+		// Conflicts with a Certificate which was created manually.
+		ingress("conflicting", "ns1", issuerAnnotation, "manual-tls", "new-tls"),
+		ingress("conflicting", "ns2", map[string]string{cmapi.IngressClusterIssuerNameAnnotationKey: "test-issuer"}, "other-tls"),
+		// Ingresses without shim annotations are ignored.
+		ingress("unannotated", "ns1", nil, "manual-tls"),
+	}))
+}
+
+func TestShimMissingCertificateCounts(t *testing.T) {
+	issuerAnnotation := map[string]string{cmapi.IngressIssuerNameAnnotationKey: "test-issuer"}
+	ingress := func(namespace string, annotations map[string]string, secretNames ...string) *networkingv1.Ingress {
+		ing := &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: namespace, Annotations: annotations},
+		}
+		for _, secretName := range secretNames {
+			ing.Spec.TLS = append(ing.Spec.TLS, networkingv1.IngressTLS{Hosts: []string{"example.com"}, SecretName: secretName})
+		}
+		return ing
+	}
+	gateway := func(namespace string, annotations map[string]string, mode gwapi.TLSModeType, refs ...gwapi.SecretObjectReference) *gwapi.Gateway {
+		hostname := gwapi.Hostname("example.com")
+		return &gwapi.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: namespace, Annotations: annotations},
+			Spec: gwapi.GatewaySpec{
+				Listeners: []gwapi.Listener{{
+					Hostname: &hostname,
+					TLS:      &gwapi.GatewayTLSConfig{Mode: &mode, CertificateRefs: refs},
+				}},
+			},
+		}
+	}
+	otherNamespace := gwapi.Namespace("ns3")
+
+	crts := []*cmapi.Certificate{
+		gen.Certificate("existing-tls", gen.SetCertificateNamespace("ns1")),
+	}
+	ingresses := []*networkingv1.Ingress{
+		ingress("ns1", issuerAnnotation, "existing-tls", "missing-tls"),
+		// The same missing Certificate is only counted once.
+		ingress("ns1", map[string]string{cmapi.IngressClusterIssuerNameAnnotationKey: "test-issuer"}, "missing-tls"),
+		// Ingresses without shim annotations are ignored.
+		ingress("ns1", nil, "unannotated-tls"),
+		// TLS entries without hosts are skipped by ingress-shim.
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-hosts", Namespace: "ns1", Annotations: issuerAnnotation},
+			Spec:       networkingv1.IngressSpec{TLS: []networkingv1.IngressTLS{{SecretName: "no-hosts-tls"}}},
+		},
+	}
+	gateways := []*gwapi.Gateway{
+		gateway("ns2", issuerAnnotation, gwapi.TLSModeTerminate,
+			gwapi.SecretObjectReference{Name: "gateway-tls"},
+			// Cross-namespace references are refused by ingress-shim.
+			gwapi.SecretObjectReference{Name: "cross-namespace-tls", Namespace: &otherNamespace},
+		),
+		// Passthrough listeners do not need a Certificate.
+		gateway("ns2", issuerAnnotation, gwapi.TLSModePassthrough, gwapi.SecretObjectReference{Name: "passthrough-tls"}),
+	}
+
+	assert.Equal(t, map[string]int{"ns1": 1, "ns2": 1}, shimMissingCertificateCounts(crts, ingresses, gateways))
+}
@@ -20,12 +20,25 @@ import (
 	"context"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	networkingv1listers "k8s.io/client-go/listers/networking/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gwlisters "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
 
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
@@ -33,6 +46,14 @@ const (
 	// ControllerName is the string used to refer to this controller
 	// when enabling or disabling it from command line flags.
 	ControllerName = "certificates-metrics"
+
+	// resyncPeriod is how often the aggregate metrics, which are computed
+	// over all Certificates rather than a single one, are recomputed.
+	resyncPeriod = time.Minute
+
+	// manualRenewalReason is the reason of the Issuing condition set when a
+	// re-issuance is manually triggered using `cmctl renew`.
+	manualRenewalReason = "ManuallyTriggered"
 )
 
 // controllerWrapper wraps the `controller` structure to make it implement
@@ -44,8 +65,22 @@ type controllerWrapper struct {
 // This controller is synced on all Certificate 'create', 'update', and
 // 'delete' events which will update the metrics for that Certificate.
 type controller struct {
-	certificateLister cmlisters.CertificateLister
+	certificateLister        cmlisters.CertificateLister
+	certificateRequestLister cmlisters.CertificateRequestLister
+	issuerLister             cmlisters.IssuerLister
+	clusterIssuerLister      cmlisters.ClusterIssuerLister
+	secretLister             internalinformers.SecretLister
+	secretInformer           internalinformers.Informer
+	ingressLister            networkingv1listers.IngressLister
+
+	// gatewayLister is nil if the Gateway API is not installed.
+	gatewayLister gwlisters.GatewayLister
 
+	// clusterResourceNamespace is the namespace in which the Secrets
+	// referenced by ClusterIssuers are stored.
+	clusterResourceNamespace string
+
+	clock   clock.Clock
 	metrics *metrics.Metrics
 }
 
@@ -55,23 +90,73 @@ func NewController(ctx *controllerpkg.Context) (*controller, workqueue.RateLimit
 
 	// obtain references to all the informers used by this controller
 	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
+	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+	secretsInformer := ctx.KubeSharedInformerFactory.Secrets()
+	ingressInformer := ctx.KubeSharedInformerFactory.Ingresses()
 
 	// Reconcile over all Certificate events. We do _not_ reconcile on Secret
 	// events that are related to Certificates. It is the responsibility of the
 	// Certificates controllers to update accordingly.
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
 
+	// Count the events processed by the Secret informer, which is shared by
+	// all controllers, to expose how much watch churn it is handling.
+	secretsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { ctx.Metrics.IncrementSecretWatchEvent("add") },
+		UpdateFunc: func(interface{}, interface{}) { ctx.Metrics.IncrementSecretWatchEvent("update") },
+		DeleteFunc: func(interface{}) { ctx.Metrics.IncrementSecretWatchEvent("delete") },
+	})
+
 	// build a list of InformerSynced functions that will be returned by the
 	// Register method.  the controller will only begin processing items once all
 	// of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		certificateInformer.Informer().HasSynced,
+		certificateRequestInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		clusterIssuerInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+		ingressInformer.Informer().HasSynced,
+	}
+
+	ctrl := &controller{
+		certificateLister:        certificateInformer.Lister(),
+		certificateRequestLister: certificateRequestInformer.Lister(),
+		issuerLister:             issuerInformer.Lister(),
+		clusterIssuerLister:      clusterIssuerInformer.Lister(),
+		secretLister:             secretsInformer.Lister(),
+		secretInformer:           secretsInformer.Informer(),
+		ingressLister:            ingressInformer.Lister(),
+		clusterResourceNamespace: ctx.IssuerOptions.ClusterResourceNamespace,
+		clock:                    ctx.Clock,
+		metrics:                  ctx.Metrics,
 	}
 
-	return &controller{
-		certificateLister: certificateInformer.Lister(),
-		metrics:           ctx.Metrics,
-	}, queue, mustSync
+	if ctx.GatewaySolverEnabled {
+		gatewayInformer := ctx.GWShared.Gateway().V1beta1().Gateways()
+		mustSync = append(mustSync, gatewayInformer.Informer().HasSynced)
+		ctrl.gatewayLister = gatewayInformer.Lister()
+	}
+
+	// Observe approvals of CertificateRequests as they happen. This is done
+	// here rather than in the approver controller, which is commonly disabled
+	// when approval is handled by an external approver.
+	certificateRequestInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: ctrl.observeApproval,
+	})
+
+	// Count re-issuances triggered by operators, to distinguish them from
+	// those triggered by the trigger controller.
+	certificateInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			ctrl.observeManualRenewal(oldObj, newObj)
+			ctrl.observeIssuance(oldObj, newObj)
+		},
+	})
+
+	return ctrl, queue, mustSync
 }
 
 func (c *controller) ProcessItem(ctx context.Context, key string) error {
@@ -100,6 +185,206 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	return nil
 }
 
+// observeApproval observes the time taken to approve a CertificateRequest when
+// its "Approved" condition is first set to True. The approval time is taken
+// from the condition's last transition time, or is the current time if it
+// was not set by the approver.
+func (c *controller) observeApproval(oldObj, newObj interface{}) {
+	oldCR, ok := oldObj.(*cmapi.CertificateRequest)
+	if !ok {
+		return
+	}
+	newCR, ok := newObj.(*cmapi.CertificateRequest)
+	if !ok {
+		return
+	}
+	if apiutil.CertificateRequestIsApproved(oldCR) || !apiutil.CertificateRequestIsApproved(newCR) {
+		return
+	}
+
+	approvedAt := c.clock.Now()
+	if cond := apiutil.GetCertificateRequestCondition(newCR, cmapi.CertificateRequestConditionApproved); cond.LastTransitionTime != nil {
+		approvedAt = cond.LastTransitionTime.Time
+	}
+	c.metrics.ObserveCertificateRequestApprovalDuration(newCR.Spec.IssuerRef, approvedAt.Sub(newCR.CreationTimestamp.Time))
+}
+
+// observeManualRenewal counts a manually triggered re-issuance when a
+// Certificate's Issuing condition is set to True with the reason used by
+// `cmctl renew`.
+func (c *controller) observeManualRenewal(oldObj, newObj interface{}) {
+	oldCrt, ok := oldObj.(*cmapi.Certificate)
+	if !ok {
+		return
+	}
+	newCrt, ok := newObj.(*cmapi.Certificate)
+	if !ok {
+		return
+	}
+	if apiutil.CertificateHasCondition(oldCrt, cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}) {
+		return
+	}
+
+	cond := apiutil.GetCertificateCondition(newCrt, cmapi.CertificateConditionIssuing)
+	if cond == nil || cond.Status != cmmeta.ConditionTrue || cond.Reason != manualRenewalReason {
+		return
+	}
+	c.metrics.IncrementCertificateManualRenewal(newCrt.Namespace)
+}
+
+// observeIssuance observes the time taken to issue a Certificate when it
+// first becomes Ready after its initial issuance. The time it became Ready is
+// taken from the condition's last transition time, or is the current time if
+// it was not set.
+func (c *controller) observeIssuance(oldObj, newObj interface{}) {
+	oldCrt, ok := oldObj.(*cmapi.Certificate)
+	if !ok {
+		return
+	}
+	newCrt, ok := newObj.(*cmapi.Certificate)
+	if !ok {
+		return
+	}
+	// Only the first issuance is observed, as later revisions measure the
+	// Certificate's age rather than the time taken to issue it.
+	if newCrt.Status.Revision == nil || *newCrt.Status.Revision != 1 {
+		return
+	}
+	ready := cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}
+	if apiutil.CertificateHasCondition(oldCrt, ready) || !apiutil.CertificateHasCondition(newCrt, ready) {
+		return
+	}
+
+	readyAt := c.clock.Now()
+	if cond := apiutil.GetCertificateCondition(newCrt, cmapi.CertificateConditionReady); cond.LastTransitionTime != nil {
+		readyAt = cond.LastTransitionTime.Time
+	}
+	ref := newCrt.Spec.IssuerRef
+	c.metrics.ObserveCertificateIssuanceDuration(ref.Name, ref.Kind, ref.Group, readyAt.Sub(newCrt.CreationTimestamp.Time))
+}
+
+// resync recomputes the aggregate metrics from the current informer caches.
+func (c *controller) resync(ctx context.Context) {
+	log := logf.FromContext(ctx)
+
+	crts, err := c.certificateLister.List(labels.Everything())
+	if err != nil {
+		log.Error(err, "failed to list Certificates to resync metrics")
+		return
+	}
+
+	reqs, err := c.certificateRequestLister.List(labels.Everything())
+	if err != nil {
+		log.Error(err, "failed to list CertificateRequests to resync metrics")
+		return
+	}
+
+	// Secrets are only read from the informer cache, so that a resync never
+	// makes a request to the API server per Certificate. When Secrets are
+	// filtered, Secrets which are only held in the metadata only cache are
+	// skipped.
+	var secrets []*corev1.Secret
+	for _, crt := range crts {
+		secret, err := internalinformers.CachedSecret(c.secretLister, crt.Namespace, crt.Spec.SecretName)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			log.Error(err, "failed to get Secret to resync metrics", "namespace", crt.Namespace, "name", crt.Spec.SecretName)
+			continue
+		}
+		secrets = append(secrets, secret)
+	}
+
+	// Keystore password Secrets are commonly shared between Certificates, so
+	// each is only fetched once.
+	var passwordSecrets []*corev1.Secret
+	seenPasswordSecrets := make(map[types.NamespacedName]struct{})
+	for _, crt := range crts {
+		if crt.Spec.Keystores == nil {
+			continue
+		}
+		var names []string
+		if jks := crt.Spec.Keystores.JKS; jks != nil && jks.Create {
+			names = append(names, jks.PasswordSecretRef.Name)
+		}
+		if pkcs12 := crt.Spec.Keystores.PKCS12; pkcs12 != nil && pkcs12.Create {
+			names = append(names, pkcs12.PasswordSecretRef.Name)
+		}
+		for _, name := range names {
+			key := types.NamespacedName{Namespace: crt.Namespace, Name: name}
+			if _, ok := seenPasswordSecrets[key]; ok {
+				continue
+			}
+			seenPasswordSecrets[key] = struct{}{}
+
+			secret, err := internalinformers.CachedSecret(c.secretLister, crt.Namespace, name)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				log.Error(err, "failed to get keystore password Secret to resync metrics", "namespace", crt.Namespace, "name", name)
+				continue
+			}
+			passwordSecrets = append(passwordSecrets, secret)
+		}
+	}
+
+	managedSecrets, err := c.secretLister.Secrets(metav1.NamespaceAll).List(labels.SelectorFromSet(labels.Set{
+		cmapi.PartOfCertManagerControllerLabelKey: "true",
+	}))
+	if err != nil {
+		log.Error(err, "failed to list Secrets to resync metrics")
+		return
+	}
+
+	issuers, err := c.issuerLister.List(labels.Everything())
+	if err != nil {
+		log.Error(err, "failed to list Issuers to resync metrics")
+		return
+	}
+
+	clusterIssuers, err := c.clusterIssuerLister.List(labels.Everything())
+	if err != nil {
+		log.Error(err, "failed to list ClusterIssuers to resync metrics")
+		return
+	}
+
+	ingresses, err := c.ingressLister.List(labels.Everything())
+	if err != nil {
+		log.Error(err, "failed to list Ingresses to resync metrics")
+		return
+	}
+
+	var gateways []*gwapi.Gateway
+	if c.gatewayLister != nil {
+		gateways, err = c.gatewayLister.List(labels.Everything())
+		if err != nil {
+			log.Error(err, "failed to list Gateways to resync metrics")
+			return
+		}
+	}
+
+	c.metrics.SetControllerResyncObjectCount(ControllerName,
+		len(crts)+len(reqs)+len(secrets)+len(passwordSecrets)+len(managedSecrets)+len(issuers)+len(clusterIssuers)+len(ingresses)+len(gateways))
+
+	c.metrics.Resync(metrics.ResyncState{
+		Certificates:             crts,
+		CertificateRequests:      reqs,
+		Secrets:                  secrets,
+		KeystorePasswordSecrets:  passwordSecrets,
+		ManagedSecrets:           managedSecrets,
+		Issuers:                  issuers,
+		ClusterIssuers:           clusterIssuers,
+		WatchedSecrets:           internalinformers.CachedSecretCount(c.secretInformer),
+		ClusterResourceNamespace: c.clusterResourceNamespace,
+	})
+	c.metrics.SetCertificatesInBackoff(certificatesInBackoff(c.clock.Now(), crts))
+	c.metrics.SetCertificateGatedFeatureBlockedCount(gatedFeatureBlockedCounts(crts))
+	c.metrics.SetShimAnnotationConflictCount(shimAnnotationConflictCounts(crts, ingresses))
+	c.metrics.SetShimMissingCertificateCount(shimMissingCertificateCounts(crts, ingresses, gateways))
+}
+
 func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
 	ctrl, queue, mustSync := NewController(ctx)
 	c.controller = ctrl
@@ -109,8 +394,12 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 
 func init() {
 	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		wrapper := &controllerWrapper{}
 		return controllerpkg.NewBuilder(ctx, ControllerName).
-			For(&controllerWrapper{}).
+			For(wrapper).
+			// The wrapped controller is only set once Register has been
+			// called, so it must not be dereferenced here.
+			With(func(ctx context.Context) { wrapper.resync(ctx) }, resyncPeriod).
 			Complete()
 	})
 }
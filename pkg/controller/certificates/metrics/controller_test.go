@@ -0,0 +1,248 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/clock"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestResyncSetsObjectCount(t *testing.T) {
+	builder := &testpkg.Builder{
+		T: t,
+		CertManagerObjects: []runtime.Object{
+			gen.Certificate("crt1", gen.SetCertificateNamespace("ns1"), gen.SetCertificateSecretName("crt1-tls")),
+			gen.CertificateRequest("cr1", gen.SetCertificateRequestNamespace("ns1")),
+			gen.Issuer("issuer1", gen.SetIssuerNamespace("ns1")),
+		},
+		KubeObjects: []runtime.Object{
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "crt1-tls", Namespace: "ns1"}},
+		},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	c, _, _ := NewController(builder.Context)
+	builder.Start()
+	builder.Metrics.Handler()
+
+	c.resync(context.Background())
+
+	// The Certificate, its Secret, the CertificateRequest and the Issuer.
+	assert.Equal(t, 4.0, builder.Metrics.Snapshot()[`certmanager_controller_resync_object_count{controller="certificates-metrics"}`])
+}
+
+func TestResyncOnlyReadsSecretsFromCache(t *testing.T) {
+	builder := &testpkg.Builder{
+		T: t,
+		CertManagerObjects: []runtime.Object{
+			gen.Certificate("crt1", gen.SetCertificateNamespace("ns1"), gen.SetCertificateSecretName("crt1-tls")),
+			gen.Certificate("crt2", gen.SetCertificateNamespace("ns1"), gen.SetCertificateSecretName("crt2-tls")),
+		},
+		KubeObjects: []runtime.Object{
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "crt1-tls", Namespace: "ns1", Labels: map[string]string{
+				cmapi.PartOfCertManagerControllerLabelKey: "true",
+			}}},
+		},
+		PartialMetadataObjects: []runtime.Object{
+			&metav1.PartialObjectMetadata{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+				ObjectMeta: metav1.ObjectMeta{Name: "crt2-tls", Namespace: "ns1"},
+			},
+		},
+	}
+	builder.Init()
+	defer builder.Stop()
+	// Unlabelled Secrets are only held in the metadata only cache.
+	builder.KubeSharedInformerFactory = internalinformers.NewFilteredSecretsKubeInformerFactory(builder.RootContext, builder.Client, builder.MetadataClient, time.Second, "")
+
+	c, _, _ := NewController(builder.Context)
+	builder.Start()
+	builder.Metrics.Handler()
+	builder.FakeKubeClient().ClearActions()
+
+	c.resync(context.Background())
+
+	for _, action := range builder.FakeKubeClient().Actions() {
+		t.Errorf("unexpected request to the API server during resync: %s %s", action.GetVerb(), action.GetResource().Resource)
+	}
+	// The Certificates and the labelled Secret, which is counted once as a
+	// Certificate Secret and once as a managed Secret.
+	assert.Equal(t, 4.0, builder.Metrics.Snapshot()[`certmanager_controller_resync_object_count{controller="certificates-metrics"}`])
+}
+
+func TestSecretWatchEventsCounted(t *testing.T) {
+	builder := &testpkg.Builder{
+		T: t,
+		KubeObjects: []runtime.Object{
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret1", Namespace: "ns1"}},
+		},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	NewController(builder.Context)
+	builder.Start()
+	builder.Metrics.Handler()
+
+	assert.Eventually(t, func() bool {
+		return builder.Metrics.Snapshot()[`certmanager_secret_watch_event_count{event_type="add"}`] == 1
+	}, wait.ForeverTestTimeout, 10*time.Millisecond)
+
+	err := builder.Client.CoreV1().Secrets("ns1").Delete(context.Background(), "secret1", metav1.DeleteOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return builder.Metrics.Snapshot()[`certmanager_secret_watch_event_count{event_type="delete"}`] == 1
+	}, wait.ForeverTestTimeout, 10*time.Millisecond)
+}
+
+func TestObserveApproval(t *testing.T) {
+	created := time.Now().Truncate(time.Second)
+	fakeClock := fakeclock.NewFakeClock(created.Add(time.Minute))
+	m := metrics.New(logr.Discard(), clock.RealClock{})
+	c := &controller{clock: fakeClock, metrics: m}
+
+	issuerRef := gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "issuer", Kind: "Issuer", Group: "cert-manager.io"})
+	pending := gen.CertificateRequest("cr1",
+		issuerRef,
+		gen.SetCertificateRequestNamespace("ns1"),
+	)
+	pending.CreationTimestamp = metav1.NewTime(created)
+	approvedAt := metav1.NewTime(created.Add(30 * time.Second))
+	approved := gen.CertificateRequestFrom(pending,
+		gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:               cmapi.CertificateRequestConditionApproved,
+			Status:             cmmeta.ConditionTrue,
+			LastTransitionTime: &approvedAt,
+		}),
+	)
+	// Approvers which do not set the last transition time are observed at the
+	// current time.
+	approvedWithoutTime := gen.CertificateRequestFrom(pending,
+		gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionApproved,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+
+	c.observeApproval(pending, approved)
+	c.observeApproval(pending, approvedWithoutTime)
+	// Updates to CertificateRequests which were already approved, or which are
+	// still not approved, are not observed.
+	c.observeApproval(approved, approved)
+	c.observeApproval(pending, pending)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, expected := range []string{
+		`certmanager_certificaterequest_approval_duration_seconds_sum{issuer_group="cert-manager.io",issuer_kind="Issuer"} 90`,
+		`certmanager_certificaterequest_approval_duration_seconds_count{issuer_group="cert-manager.io",issuer_kind="Issuer"} 2`,
+	} {
+		assert.Contains(t, rec.Body.String(), expected)
+	}
+}
+
+func TestObserveManualRenewal(t *testing.T) {
+	m := metrics.New(logr.Discard(), clock.RealClock{})
+	m.Handler()
+	c := &controller{clock: clock.RealClock{}, metrics: m}
+
+	issuing := func(reason string) gen.CertificateModifier {
+		return gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionIssuing,
+			Status: cmmeta.ConditionTrue,
+			Reason: reason,
+		})
+	}
+	idle := gen.Certificate("crt1", gen.SetCertificateNamespace("ns1"))
+	manual := gen.CertificateFrom(idle, issuing("ManuallyTriggered"))
+	automatic := gen.CertificateFrom(idle, issuing("Expired"))
+
+	c.observeManualRenewal(idle, manual)
+	// Re-issuances triggered by the trigger controller are not counted.
+	c.observeManualRenewal(idle, automatic)
+	// Updates to Certificates which were already issuing are not counted.
+	c.observeManualRenewal(manual, manual)
+
+	assert.Equal(t, 1.0, m.Snapshot()[`certmanager_certificate_manual_renewal_count{namespace="ns1"}`])
+}
+
+func TestObserveIssuance(t *testing.T) {
+	created := time.Now().Truncate(time.Second)
+	m := metrics.New(logr.Discard(), clock.RealClock{})
+	c := &controller{clock: fakeclock.NewFakeClock(created.Add(time.Minute)), metrics: m}
+
+	pending := gen.Certificate("crt1",
+		gen.SetCertificateNamespace("ns1"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "cert-manager.io"}),
+	)
+	pending.CreationTimestamp = metav1.NewTime(created)
+	readyAt := metav1.NewTime(created.Add(20 * time.Second))
+	ready := gen.CertificateFrom(pending,
+		gen.SetCertificateRevision(1),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionReady,
+			Status:             cmmeta.ConditionTrue,
+			LastTransitionTime: &readyAt,
+		}),
+	)
+	// Certificates which do not set the last transition time are observed at
+	// the current time.
+	readyWithoutTime := gen.CertificateFrom(pending,
+		gen.SetCertificateRevision(1),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+	renewed := gen.CertificateFrom(ready, gen.SetCertificateRevision(2))
+
+	c.observeIssuance(pending, ready)
+	c.observeIssuance(pending, readyWithoutTime)
+	// Certificates which were already Ready, or which became Ready after a
+	// later issuance, are not observed.
+	c.observeIssuance(ready, ready)
+	c.observeIssuance(pending, renewed)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, expected := range []string{
+		`certmanager_certificate_issuance_duration_seconds_sum{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca"} 80`,
+		`certmanager_certificate_issuance_duration_seconds_count{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca"} 2`,
+	} {
+		assert.Contains(t, rec.Body.String(), expected)
+	}
+}
@@ -41,6 +41,7 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/pkg/util/predicate"
@@ -70,6 +71,9 @@ type controller struct {
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Apply API calls.
 	fieldManager string
+
+	// metrics is used to count reconciles which did not change the status.
+	metrics *metrics.Metrics
 }
 
 // readyConditionFunc is custom function type that builds certificate's Ready condition
@@ -125,6 +129,7 @@ func NewController(
 		policyEvaluator:       policyEvaluator,
 		renewalTimeCalculator: renewalTimeCalculator,
 		fieldManager:          ctx.FieldManager,
+		metrics:               ctx.Metrics,
 	}, queue, mustSync
 }
 
@@ -193,6 +198,8 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 			crt.Status.RenewalTime)
 		return c.updateOrApplyStatus(ctx, crt)
 	}
+
+	c.metrics.IncrementNoopReconcileCount(ControllerName)
 	return nil
 }
 
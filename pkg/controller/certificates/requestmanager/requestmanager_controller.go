@@ -46,6 +46,7 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/pkg/util/predicate"
@@ -69,6 +70,7 @@ type controller struct {
 	recorder                 record.EventRecorder
 	clock                    clock.Clock
 	copiedAnnotationPrefixes []string
+	metrics                  *metrics.Metrics
 
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
@@ -116,6 +118,7 @@ func NewController(
 		recorder:                 ctx.Recorder,
 		clock:                    ctx.Clock,
 		copiedAnnotationPrefixes: ctx.CertificateOptions.CopiedAnnotationPrefixes,
+		metrics:                  ctx.Metrics,
 		fieldManager:             ctx.FieldManager,
 	}, queue, mustSync
 }
@@ -404,6 +407,7 @@ func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi
 	}
 
 	c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonRequested, "Created new CertificateRequest resource %q", cr.Name)
+	c.metrics.IncrementCertificateTriggeredRequest(crt.Spec.IssuerRef.Kind)
 
 	// If the StableCertificateRequestName feature gate is enabled, skip waiting for our informer cache/lister to
 	// observe the creation event and instead rely on an AlreadyExists error being returned if we do attempt a
@@ -647,6 +647,7 @@ func TestProcessItem(t *testing.T) {
 			}
 			builder.CertManagerObjects = append(builder.CertManagerObjects, test.requests...)
 			builder.Init()
+			builder.Metrics.Handler()
 
 			// Register informers used by the controller using the registration wrapper
 			w := &controllerWrapper{}
@@ -694,6 +695,18 @@ func TestProcessItem(t *testing.T) {
 			if err := builder.AllReactorsCalled(); err != nil {
 				builder.T.Error(err)
 			}
+
+			// The triggered request count should be increased once for each
+			// CertificateRequest created.
+			expCreated := 0.0
+			for _, action := range test.expectedActions {
+				if action.Action().GetVerb() == "create" && action.Action().GetResource().Resource == "certificaterequests" {
+					expCreated++
+				}
+			}
+			if got := builder.Metrics.Snapshot()[`certmanager_certificate_triggered_request_count{issuer_kind=""}`]; got != expCreated {
+				t.Errorf("expected triggered request count to be %v, got %v", expCreated, got)
+			}
 		})
 	}
 }
@@ -19,7 +19,7 @@ package trigger
 import (
 	"context"
 	"fmt"
-	"math"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -44,6 +44,7 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/scheduler"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -52,10 +53,6 @@ import (
 
 const (
 	ControllerName = "certificates-trigger"
-	// stopIncreaseBackoff is the number of issuance attempts after which the backoff period should stop to increase
-	stopIncreaseBackoff = 6 // 2 ^ (6 - 1) = 32 = maxDelay
-	// maxDelay is the maximum backoff period
-	maxDelay = 32 * time.Hour
 )
 
 // This controller observes the state of the certificate's currently
@@ -76,6 +73,15 @@ type controller struct {
 	// Apply API calls.
 	fieldManager string
 
+	// metrics is used to count reconciles which did not trigger an issuance.
+	metrics *metrics.Metrics
+
+	// scheduledRenewals is the renewal each Certificate was last scheduled
+	// for, keyed by the Certificate's key. It is used to count renewals which
+	// are rescheduled.
+	scheduledRenewals     map[string]scheduledRenewal
+	scheduledRenewalsLock sync.Mutex
+
 	// The following are used for testing purposes.
 	clock              clock.Clock
 	shouldReissue      policies.Func
@@ -124,6 +130,8 @@ func NewController(
 		recorder:                 ctx.Recorder,
 		scheduledWorkQueue:       scheduler.NewScheduledWorkQueue(ctx.Clock, queue.Add),
 		fieldManager:             ctx.FieldManager,
+		metrics:                  ctx.Metrics,
+		scheduledRenewals:        make(map[string]scheduledRenewal),
 
 		// The following are used for testing purposes.
 		clock:         ctx.Clock,
@@ -147,6 +155,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	crt, err := c.certificateLister.Certificates(namespace).Get(name)
 	if apierrors.IsNotFound(err) {
 		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		c.forgetRenewalTime(key)
 		return nil
 	}
 	if err != nil {
@@ -178,12 +187,14 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if crt.Status.RenewalTime != nil {
 		// ensure a resync is scheduled in the future so that we re-check
 		// Certificate resources and trigger them near expiry time
+		c.recordRenewalTime(key, crt)
 		c.scheduleRecheckOfCertificateIfRequired(log, key, crt.Status.RenewalTime.Time.Sub(c.clock.Now()))
 	}
 
 	reason, message, reissue := c.shouldReissue(input)
 	if !reissue {
 		// no re-issuance required, return early
+		c.metrics.IncrementNoopReconcileCount(ControllerName)
 		return nil
 	}
 
@@ -266,32 +277,7 @@ func shouldBackoffReissuingOnFailure(log logr.Logger, c clock.Clock, crt *cmapi.
 	now := c.Now()
 	durationSinceFailure := now.Sub(crt.Status.LastFailureTime.Time)
 
-	initialDelay := time.Hour
-	delay := initialDelay
-	failedIssuanceAttempts := 0
-	// It is possible that crt.Status.LastFailureTime != nil &&
-	// crt.Status.FailedIssuanceAttempts == nil (in case of the Certificate having
-	// failed for an installation of cert-manager before the issuance
-	// attempts were introduced). In such case delay = initialDelay.
-	if crt.Status.FailedIssuanceAttempts != nil {
-		failedIssuanceAttempts = *crt.Status.FailedIssuanceAttempts
-		delay = time.Hour * time.Duration(math.Pow(2, float64(failedIssuanceAttempts-1)))
-	}
-
-	// Ensure that maximum returned delay is 32 hours
-	// delay cannot be calculated for large issuance numbers, so we
-	// cannot reliably check if delay > maxDelay directly
-	// (see i.e the result of time.Duration(math.Pow(2, 99)))
-	if failedIssuanceAttempts > stopIncreaseBackoff {
-		delay = maxDelay
-	}
-
-	// Ensure that minimum returned delay is 1 hour. This is here to guard
-	// against an edge case where the delay duration got messed
-	// up as a result of maths misuse in the previous calculations
-	if delay < initialDelay {
-		delay = initialDelay
-	}
+	delay := internalcertificates.IssuanceBackoffDelay(crt)
 
 	if durationSinceFailure >= delay {
 		log.V(logf.ExtendedInfoLevel).WithValues("since_failure", durationSinceFailure).Info("Certificate has been in failure state long enough, no need to back off")
@@ -322,6 +308,49 @@ func (c *controller) scheduleRecheckOfCertificateIfRequired(log logr.Logger, key
 	c.scheduledWorkQueue.Add(key, durationUntilRenewalTime)
 }
 
+// scheduledRenewal is the renewal time a Certificate was scheduled for, along
+// with the certificate it was computed from.
+type scheduledRenewal struct {
+	renewalTime time.Time
+	notAfter    time.Time
+	revision    int
+}
+
+// recordRenewalTime records the renewal time the Certificate with the given
+// key is being scheduled for, and counts the renewal as rescheduled if it
+// differs from the renewal time it was last scheduled for. A renewal time
+// which moves later because a new certificate was issued is expected, so is
+// only counted if it moved earlier or the certificate is unchanged.
+func (c *controller) recordRenewalTime(key string, crt *cmapi.Certificate) {
+	c.scheduledRenewalsLock.Lock()
+	defer c.scheduledRenewalsLock.Unlock()
+
+	renewal := scheduledRenewal{renewalTime: crt.Status.RenewalTime.Time}
+	if crt.Status.NotAfter != nil {
+		renewal.notAfter = crt.Status.NotAfter.Time
+	}
+	if crt.Status.Revision != nil {
+		renewal.revision = *crt.Status.Revision
+	}
+
+	if previous, ok := c.scheduledRenewals[key]; ok && !previous.renewalTime.Equal(renewal.renewalTime) {
+		certificateUnchanged := previous.notAfter.Equal(renewal.notAfter) && previous.revision == renewal.revision
+		if certificateUnchanged || renewal.renewalTime.Before(previous.renewalTime) {
+			c.metrics.IncrementCertificateRenewalReschedule(crt.Spec.IssuerRef.Kind)
+		}
+	}
+	c.scheduledRenewals[key] = renewal
+}
+
+// forgetRenewalTime removes the recorded renewal time of a Certificate which
+// no longer exists.
+func (c *controller) forgetRenewalTime(key string) {
+	c.scheduledRenewalsLock.Lock()
+	defer c.scheduledRenewalsLock.Unlock()
+
+	delete(c.scheduledRenewals, key)
+}
+
 // controllerWrapper wraps the `controller` structure to make it implement
 // the controllerpkg.queueingController interface
 type controllerWrapper struct {
@@ -26,13 +26,16 @@ import (
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	coretesting "k8s.io/client-go/testing"
+	"k8s.io/utils/clock"
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
@@ -679,3 +682,46 @@ func Test_shouldBackoffReissuingOnFailure(t *testing.T) {
 
 	}
 }
+
+func Test_recordRenewalTime(t *testing.T) {
+	m := metrics.New(logtesting.NewTestLogger(t), clock.RealClock{})
+	// Metrics are only exposed in a snapshot once registered.
+	m.Handler()
+	c := &controller{
+		metrics:           m,
+		scheduledRenewals: make(map[string]scheduledRenewal),
+	}
+
+	now := time.Now()
+	crtRenewingAt := func(renewalTime, notAfter time.Time, revision int) *cmapi.Certificate {
+		return gen.Certificate("test",
+			gen.SetCertificateNamespace("testns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer"}),
+			gen.SetCertificateRenewalTime(metav1.NewTime(renewalTime)),
+			gen.SetCertificateNotAfter(metav1.NewTime(notAfter)),
+			gen.SetCertificateRevision(revision),
+		)
+	}
+	notAfter := now.Add(3 * time.Hour)
+
+	// The first schedule, and schedules for an unchanged renewal time, are
+	// not counted.
+	c.recordRenewalTime("testns/test", crtRenewingAt(now, notAfter, 1))
+	c.recordRenewalTime("testns/test", crtRenewingAt(now, notAfter, 1))
+
+	// A renewal time which moves for the same certificate is counted, whether
+	// it moves later or earlier.
+	c.recordRenewalTime("testns/test", crtRenewingAt(now.Add(time.Hour), notAfter, 1))
+	c.recordRenewalTime("testns/test", crtRenewingAt(now, notAfter, 1))
+
+	// A renewal time which moves later because a new certificate was issued
+	// is not counted, but one which moves earlier is.
+	c.recordRenewalTime("testns/test", crtRenewingAt(now.Add(2*time.Hour), notAfter.Add(2*time.Hour), 2))
+	c.recordRenewalTime("testns/test", crtRenewingAt(now.Add(time.Hour), notAfter.Add(time.Hour), 3))
+
+	// Once forgotten, the next schedule is treated as the first.
+	c.forgetRenewalTime("testns/test")
+	c.recordRenewalTime("testns/test", crtRenewingAt(now.Add(time.Hour), notAfter, 1))
+
+	assert.Equal(t, 3.0, m.Snapshot()[`certmanager_certificate_renewal_reschedule_count{issuer_kind="Issuer"}`])
+}
@@ -105,6 +105,8 @@ func (c *controller) Run(workers int, stopCh <-chan struct{}) error {
 		return fmt.Errorf("error waiting for informer caches to sync")
 	}
 
+	c.metrics.SetControllerWorkersTotal(c.name, workers)
+
 	var wg sync.WaitGroup
 	for i := 0; i < workers; i++ {
 		wg.Add(1)
@@ -156,6 +158,9 @@ func (c *controller) worker(ctx context.Context) {
 			// Increase sync count for this controller
 			c.metrics.IncrementSyncCallCount(c.name)
 
+			c.metrics.IncrementControllerWorkersBusy(c.name)
+			defer c.metrics.DecrementControllerWorkersBusy(c.name)
+
 			err := c.syncHandler(ctx, key)
 			if err != nil {
 				if strings.Contains(err.Error(), genericregistry.OptimisticLockErrorMsg) {
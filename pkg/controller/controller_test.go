@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+func TestControllerWorkersMetrics(t *testing.T) {
+	m := metrics.New(logr.Discard(), clock.RealClock{})
+	// Metrics are only exposed in a snapshot once registered.
+	m.Handler()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	ctrl := NewController(context.Background(), "test", m, func(ctx context.Context, key string) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}, nil, nil, queue)
+
+	stopCh := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, ctrl.Run(2, stopCh))
+	}()
+
+	queue.Add("test/key")
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for item to be processed")
+	}
+
+	snapshot := m.Snapshot()
+	assert.Equal(t, 2.0, snapshot[`certmanager_controller_workers_total{controller="test"}`])
+	assert.Equal(t, 1.0, snapshot[`certmanager_controller_workers_busy{controller="test"}`])
+
+	close(release)
+	close(stopCh)
+	<-done
+
+	assert.Equal(t, 0.0, m.Snapshot()[`certmanager_controller_workers_busy{controller="test"}`])
+}
@@ -86,7 +86,9 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 	}
 	if err == nil {
 		log.V(logf.InfoLevel).Info("presenting DNS01 challenge for domain")
-		return webhookSolver.Present(req)
+		err := webhookSolver.Present(req)
+		s.observeRateLimitError(webhookSolver.Name(), err)
+		return err
 	}
 
 	slv, providerConfig, err := s.solverForChallenge(ctx, issuer, ch)
@@ -101,7 +103,9 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 
 	log.V(logf.DebugLevel).Info("presenting DNS01 challenge for domain")
 
-	return slv.Present(ch.Spec.DNSName, fqdn, ch.Spec.Key)
+	err = slv.Present(ch.Spec.DNSName, fqdn, ch.Spec.Key)
+	s.observeRateLimitError(providerName(providerConfig), err)
+	return err
 }
 
 // Check verifies that the DNS records for the ACME challenge have propagated.
@@ -144,7 +148,9 @@ func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 	}
 	if err == nil {
 		log.V(logf.DebugLevel).Info("cleaning up DNS01 challenge")
-		return webhookSolver.CleanUp(req)
+		err := webhookSolver.CleanUp(req)
+		s.observeRateLimitError(webhookSolver.Name(), err)
+		return err
 	}
 
 	slv, providerConfig, err := s.solverForChallenge(ctx, issuer, ch)
@@ -157,7 +163,69 @@ func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 		return err
 	}
 
-	return slv.CleanUp(ch.Spec.DNSName, fqdn, ch.Spec.Key)
+	err = slv.CleanUp(ch.Spec.DNSName, fqdn, ch.Spec.Key)
+	s.observeRateLimitError(providerName(providerConfig), err)
+	return err
+}
+
+// rateLimitErrorSubstrings are the lower-cased fragments of error messages
+// returned by DNS providers when they are rate limiting requests. Most
+// providers stringify the underlying API errors, so the message is all that
+// can be relied upon.
+var rateLimitErrorSubstrings = []string{
+	"rate limit",
+	"ratelimit",
+	"throttl",
+	"too many requests",
+	"429",
+}
+
+// isRateLimitError returns true if the error returned by a DNS provider
+// indicates that the provider is rate limiting requests.
+func isRateLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, substr := range rateLimitErrorSubstrings {
+		if strings.Contains(msg, substr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// observeRateLimitError counts errors returned by the given provider which
+// indicate that it is rate limiting requests.
+func (s *Solver) observeRateLimitError(provider string, err error) {
+	if isRateLimitError(err) {
+		s.Metrics.IncrementACMEDNS01RateLimited(provider)
+	}
+}
+
+// providerName returns the name of the DNS provider configured for the
+// solver, for use in metrics.
+func providerName(config *cmacme.ACMEChallengeSolverDNS01) string {
+	switch {
+	case config.Akamai != nil:
+		return "akamai"
+	case config.CloudDNS != nil:
+		return "clouddns"
+	case config.Cloudflare != nil:
+		return "cloudflare"
+	case config.DigitalOcean != nil:
+		return "digitalocean"
+	case config.Route53 != nil:
+		return "route53"
+	case config.AzureDNS != nil:
+		return "azuredns"
+	case config.AcmeDNS != nil:
+		return "acmedns"
+	default:
+		return "unknown"
+	}
 }
 
 func followCNAME(strategy cmacme.CNAMEStrategy) bool {
@@ -18,6 +18,7 @@ package dns
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -655,3 +656,39 @@ func TestRoute53AssumeRole(t *testing.T) {
 		}
 	}
 }
+
+func TestIsRateLimitError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"nil error": {
+			err:      nil,
+			expected: false,
+		},
+		"unrelated error": {
+			err:      errors.New("failed to find zone"),
+			expected: false,
+		},
+		"route53 throttling": {
+			err:      errors.New("failed to change Route 53 record set: Throttling: Rate exceeded"),
+			expected: true,
+		},
+		"http too many requests": {
+			err:      errors.New("cloudflare: Too Many Requests"),
+			expected: true,
+		},
+		"rate limit message": {
+			err:      errors.New("API rate limit exceeded"),
+			expected: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isRateLimitError(test.err); got != test.expected {
+				t.Errorf("expected isRateLimitError to return %t, got %t", test.expected, got)
+			}
+		})
+	}
+}
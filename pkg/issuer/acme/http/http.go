@@ -68,7 +68,10 @@ type Solver struct {
 	requiredPasses   int
 }
 
-type reachabilityTest func(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string) error
+// reachabilityTest checks that the challenge is reachable at the given url,
+// returning the HTTP status code of the response, or 0 if no response was
+// received.
+type reachabilityTest func(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string) (int, error)
 
 // NewSolver returns a new ACME HTTP01 solver for the given *controller.Context.
 func NewSolver(ctx *controller.Context) (*Solver, error) {
@@ -161,7 +164,10 @@ func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.
 
 	log.V(logf.DebugLevel).Info("running self check multiple times to ensure challenge has propagated", "required_passes", s.requiredPasses)
 	for i := 0; i < s.requiredPasses; i++ {
-		err := s.testReachability(ctx, url, ch.Spec.Key, s.HTTP01SolverNameservers, s.Context.RESTConfig.UserAgent)
+		code, err := s.testReachability(ctx, url, ch.Spec.Key, s.HTTP01SolverNameservers, s.Context.RESTConfig.UserAgent)
+		if code != 0 {
+			s.Metrics.IncrementACMEHTTP01SelfCheckResponseCode(code)
+		}
 		if err != nil {
 			return err
 		}
@@ -203,14 +209,15 @@ func (s *Solver) buildChallengeUrl(ch *cmacme.Challenge) *url.URL {
 }
 
 // testReachability will attempt to connect to the 'domain' with 'path' and
-// check if the returned body equals 'key'
-func testReachability(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string) error {
+// check if the returned body equals 'key'. The status code of the response is
+// returned, or 0 if no response was received.
+func testReachability(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string) (int, error) {
 	log := logf.FromContext(ctx)
 	log.V(logf.DebugLevel).Info("performing HTTP01 reachability check")
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	req.Header.Set("User-Agent", userAgent)
 
@@ -292,19 +299,19 @@ func testReachability(ctx context.Context, url *url.URL, key string, dnsServers
 	response, err := client.Do(req)
 	if err != nil {
 		log.V(logf.DebugLevel).Info("failed to perform self check GET request", "error", err)
-		return fmt.Errorf("failed to perform self check GET request '%s': %v", url, err)
+		return 0, fmt.Errorf("failed to perform self check GET request '%s': %v", url, err)
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
 		log.V(logf.DebugLevel).Info("received HTTP status code was not StatusOK (200)", "code", response.StatusCode)
-		return fmt.Errorf("wrong status code '%d', expected '%d'", response.StatusCode, http.StatusOK)
+		return response.StatusCode, fmt.Errorf("wrong status code '%d', expected '%d'", response.StatusCode, http.StatusOK)
 	}
 
 	presentedKey, err := io.ReadAll(response.Body)
 	if err != nil {
 		log.V(logf.DebugLevel).Info("failed to decode response body", "error", err)
-		return fmt.Errorf("failed to read response body: %v", err)
+		return response.StatusCode, fmt.Errorf("failed to read response body: %v", err)
 	}
 
 	if string(presentedKey) != key {
@@ -316,10 +323,10 @@ func testReachability(ctx context.Context, url *url.URL, key string, dnsServers
 			keyToPrint = strings.TrimSpace(keyToPrint[:24]) + "... (truncated)"
 		}
 		log.V(logf.DebugLevel).Info("key returned by server did not match expected", "actual", keyToPrint, "expected", key)
-		return fmt.Errorf("did not get expected response when querying endpoint, expected %q but got: %s", key, keyToPrint)
+		return response.StatusCode, fmt.Errorf("did not get expected response when querying endpoint, expected %q but got: %s", key, keyToPrint)
 	}
 
 	log.V(logf.DebugLevel).Info("reachability test succeeded")
 
-	return nil
+	return response.StatusCode, nil
 }
@@ -20,22 +20,26 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"strings"
 	"sync/atomic"
 	"testing"
 
+	logtesting "github.com/go-logr/logr/testing"
 	"github.com/miekg/dns"
 	"k8s.io/client-go/rest"
+	"k8s.io/utils/clock"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
 // countReachabilityTestCalls is a wrapper function that allows us to count the number
 // of calls to a reachabilityTest.
 func countReachabilityTestCalls(counter *int, t reachabilityTest) reachabilityTest {
-	return func(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string) error {
+	return func(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string) (int, error) {
 		*counter++
 		return t(ctx, url, key, dnsServers, userAgent)
 	}
@@ -51,15 +55,15 @@ func TestCheck(t *testing.T) {
 	tests := []testT{
 		{
 			name: "should pass",
-			reachabilityTest: func(context.Context, *url.URL, string, []string, string) error {
-				return nil
+			reachabilityTest: func(context.Context, *url.URL, string, []string, string) (int, error) {
+				return http.StatusOK, nil
 			},
 			expectedErr: false,
 		},
 		{
 			name: "should error",
-			reachabilityTest: func(context.Context, *url.URL, string, []string, string) error {
-				return fmt.Errorf("failed")
+			reachabilityTest: func(context.Context, *url.URL, string, []string, string) (int, error) {
+				return http.StatusNotFound, fmt.Errorf("failed")
 			},
 			expectedErr: true,
 		},
@@ -74,7 +78,12 @@ func TestCheck(t *testing.T) {
 				test.challenge = &cmacme.Challenge{}
 			}
 			s := Solver{
-				Context:          &controller.Context{RESTConfig: new(rest.Config)},
+				Context: &controller.Context{
+					RESTConfig: new(rest.Config),
+					ContextOptions: controller.ContextOptions{
+						Metrics: metrics.New(logtesting.NewTestLogger(t), clock.RealClock{}),
+					},
+				},
 				testReachability: countReachabilityTestCalls(&calls, test.reachabilityTest),
 				requiredPasses:   requiredCallsForPass,
 			}
@@ -176,7 +185,7 @@ func TestReachabilityCustomDnsServers(t *testing.T) {
 
 	for _, tt := range tests {
 		atomic.StoreInt32(&dnsServerCalled, 0)
-		err = testReachability(context.Background(), u, key, tt.dnsServers, "cert-manager-test")
+		_, err = testReachability(context.Background(), u, key, tt.dnsServers, "cert-manager-test")
 		switch {
 		case err == nil:
 			t.Errorf("Expected error for testReachability, but got none")
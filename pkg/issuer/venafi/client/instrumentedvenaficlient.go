@@ -17,6 +17,7 @@ limitations under the License.
 package client
 
 import (
+	"errors"
 	"time"
 
 	"github.com/Venafi/vcert/v4/pkg/certificate"
@@ -27,6 +28,18 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
+const (
+	// requestStatusSuccess is the status of Venafi requests which succeeded.
+	requestStatusSuccess = "success"
+
+	// requestStatusPending is the status of requests to retrieve a
+	// certificate which has not been issued yet.
+	requestStatusPending = "pending"
+
+	// requestStatusError is the status of Venafi requests which failed.
+	requestStatusError = "error"
+)
+
 type instrumentedConnector struct {
 	conn    connector
 	metrics *metrics.Metrics
@@ -49,6 +62,7 @@ func (ic instrumentedConnector) ReadZoneConfiguration() (*endpoint.ZoneConfigura
 	config, err := ic.conn.ReadZoneConfiguration()
 	labels := []string{"read_zone_configuration"}
 	ic.metrics.ObserveVenafiRequestDuration(time.Since(start), labels...)
+	ic.metrics.IncrementVenafiRequestCount("read_zone_configuration", requestStatus(err))
 	return config, err
 }
 
@@ -58,6 +72,7 @@ func (ic instrumentedConnector) RequestCertificate(req *certificate.Request) (st
 	reqID, err := ic.conn.RequestCertificate(req)
 	labels := []string{"request_certificate"}
 	ic.metrics.ObserveVenafiRequestDuration(time.Since(start), labels...)
+	ic.metrics.IncrementVenafiRequestCount("request_certificate", requestStatus(err))
 	return reqID, err
 }
 
@@ -67,6 +82,7 @@ func (ic instrumentedConnector) RetrieveCertificate(req *certificate.Request) (*
 	pemCollection, err := ic.conn.RetrieveCertificate(req)
 	labels := []string{"retrieve_certificate"}
 	ic.metrics.ObserveVenafiRequestDuration(time.Since(start), labels...)
+	ic.metrics.IncrementVenafiRequestCount("retrieve_certificate", requestStatus(err))
 	return pemCollection, err
 }
 
@@ -76,6 +92,7 @@ func (ic instrumentedConnector) Ping() error {
 	err := ic.conn.Ping()
 	labels := []string{"ping"}
 	ic.metrics.ObserveVenafiRequestDuration(time.Since(start), labels...)
+	ic.metrics.IncrementVenafiRequestCount("ping", requestStatus(err))
 	return err
 }
 
@@ -85,5 +102,20 @@ func (ic instrumentedConnector) RenewCertificate(req *certificate.RenewalRequest
 	reqID, err := ic.conn.RenewCertificate(req)
 	labels := []string{"renew_certificate"}
 	ic.metrics.ObserveVenafiRequestDuration(time.Since(start), labels...)
+	ic.metrics.IncrementVenafiRequestCount("renew_certificate", requestStatus(err))
 	return reqID, err
 }
+
+// requestStatus returns the status label value of a Venafi request which
+// returned the given error. Certificates which are still being issued are
+// reported as pending rather than as errors, as polling for them is expected.
+func requestStatus(err error) string {
+	switch {
+	case err == nil:
+		return requestStatusSuccess
+	case errors.As(err, &endpoint.ErrCertificatePending{}), errors.As(err, &endpoint.ErrRetrieveCertificateTimeout{}):
+		return requestStatusPending
+	default:
+		return requestStatusError
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Venafi/vcert/v4/pkg/endpoint"
+)
+
+func TestRequestStatus(t *testing.T) {
+	tests := map[string]struct {
+		err error
+		exp string
+	}{
+		"no error": {
+			exp: requestStatusSuccess,
+		},
+		"certificate pending": {
+			err: endpoint.ErrCertificatePending{CertificateID: "id", Status: "issuing"},
+			exp: requestStatusPending,
+		},
+		"retrieve timeout": {
+			err: fmt.Errorf("retrieving certificate: %w", endpoint.ErrRetrieveCertificateTimeout{CertificateID: "id"}),
+			exp: requestStatusPending,
+		},
+		"other error": {
+			err: errors.New("connection refused"),
+			exp: requestStatusError,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := requestStatus(test.err); got != test.exp {
+				t.Errorf("expected status %q, got %q", test.exp, got)
+			}
+		})
+	}
+}
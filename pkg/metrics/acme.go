@@ -17,15 +17,75 @@ limitations under the License.
 package metrics
 
 import (
+	"strconv"
 	"time"
 )
 
 // ObserveACMERequestDuration increases bucket counters for that ACME client duration.
+// It is observed by both the deprecated summary and the histogram which
+// replaces it.
 func (m *Metrics) ObserveACMERequestDuration(duration time.Duration, labels ...string) {
 	m.acmeClientRequestDurationSeconds.WithLabelValues(labels...).Observe(duration.Seconds())
+	m.acmeClientRequestDurationSecondsHistogram.WithLabelValues(labels...).Observe(duration.Seconds())
 }
 
 // IncrementACMERequestCount increases the acme client request counter.
 func (m *Metrics) IncrementACMERequestCount(labels ...string) {
 	m.acmeClientRequestCount.WithLabelValues(labels...).Inc()
 }
+
+// IncrementACMERequestsInFlight increases the number of HTTP requests to the
+// ACME server on the given host which are in flight. It must be followed by a
+// call to DecrementACMERequestsInFlight once the request has completed.
+func (m *Metrics) IncrementACMERequestsInFlight(host string) {
+	m.acmeClientRequestsInFlight.WithLabelValues(host).Inc()
+}
+
+// DecrementACMERequestsInFlight decreases the number of HTTP requests to the
+// ACME server on the given host which are in flight.
+func (m *Metrics) DecrementACMERequestsInFlight(host string) {
+	m.acmeClientRequestsInFlight.WithLabelValues(host).Dec()
+}
+
+// IncrementACMEProblem increases the count of problem documents of the given
+// type returned by the ACME server on the given host.
+func (m *Metrics) IncrementACMEProblem(host, problemType string) {
+	m.acmeClientProblemCount.WithLabelValues(host, problemType).Inc()
+}
+
+// IncrementACMEDNS01RateLimited increases the count of rate limit errors
+// returned by the given DNS01 provider.
+func (m *Metrics) IncrementACMEDNS01RateLimited(provider string) {
+	m.acmeDNS01RateLimitedCount.WithLabelValues(provider).Inc()
+}
+
+// IncrementACMEHTTP01SelfCheckResponseCode increases the count of HTTP01
+// self check responses with the given status code.
+func (m *Metrics) IncrementACMEHTTP01SelfCheckResponseCode(code int) {
+	m.acmeHTTP01SelfCheckResponseCodeCount.WithLabelValues(strconv.Itoa(code)).Inc()
+}
+
+// IncrementACMEAuthorizationReused increases the count of ACME authorizations
+// for Orders of the named issuer which were already valid, so were reused
+// without solving a challenge.
+func (m *Metrics) IncrementACMEAuthorizationReused(issuerName, namespace string) {
+	m.acmeAuthorizationReusedCount.WithLabelValues(issuerName, namespace).Inc()
+}
+
+// ObserveACMENewOrderDuration records how long it took to create a new order
+// with the ACME server on the given host.
+func (m *Metrics) ObserveACMENewOrderDuration(host string, duration time.Duration) {
+	m.acmeNewOrderDurationSeconds.WithLabelValues(host).Observe(duration.Seconds())
+}
+
+// ObserveACMEOrderFinalizeDuration records how long it took to finalize an
+// order with the ACME server on the given host and download its certificate.
+func (m *Metrics) ObserveACMEOrderFinalizeDuration(host string, duration time.Duration) {
+	m.acmeOrderFinalizeDurationSeconds.WithLabelValues(host).Observe(duration.Seconds())
+}
+
+// ObserveACMEChallengeCleanupLag records the time between a challenge of the
+// given type becoming valid and its solver resources being cleaned up.
+func (m *Metrics) ObserveACMEChallengeCleanupLag(challengeType string, lag time.Duration) {
+	m.acmeChallengeCleanupLagSeconds.WithLabelValues(challengeType).Observe(lag.Seconds())
+}
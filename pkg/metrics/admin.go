@@ -0,0 +1,125 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// adminMetricsPath is the path prefix of the admin endpoints, which are
+// served as POST {adminMetricsPath}{name}/disable and
+// POST {adminMetricsPath}{name}/enable.
+const adminMetricsPath = "/admin/metrics/"
+
+// adminHandler returns an HTTP handler which disables or re-enables the
+// collector with the fully-qualified metric name given in the request path.
+// Disabled metrics are unregistered, so are no longer exposed until they are
+// re-enabled. Metric values are kept while a metric is disabled. Requests are
+// not authenticated or authorized by the handler itself; NewServer wraps it
+// with authenticate and authorizeAdmin.
+func (m *Metrics) adminHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		name, action, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, adminMetricsPath), "/")
+		if !ok || name == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		m.registerOnce.Do(m.register)
+		collector, ok := m.collectors[name]
+		if !ok {
+			http.Error(w, "unknown metric "+name, http.StatusNotFound)
+			return
+		}
+
+		switch action {
+		case "disable":
+			m.registry.Unregister(collector)
+			m.log.Info("disabled metric", "metric", name)
+		case "enable":
+			if err := m.registry.Register(collector); err != nil && !errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+				m.log.Error(err, "failed to enable metric", "metric", name)
+				http.Error(w, "failed to enable metric "+name, http.StatusInternalServerError)
+				return
+			}
+			m.log.Info("enabled metric", "metric", name)
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// authorizeAdmin returns an HTTP handler which only passes requests to next if
+// a SubjectAccessReview allows the user authenticated by authenticate to use
+// the request method as verb on the request path, e.g. with a ClusterRole rule
+// granting the `post` verb on the non-resource URL `/admin/metrics/*`.
+// Requests which are not allowed are refused with 403 Forbidden.
+func (m *Metrics) authorizeAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := authenticatedUser(r.Context())
+		if !ok {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+		for k, v := range user.Extra {
+			extra[k] = authorizationv1.ExtraValue(v)
+		}
+
+		review, err := m.opts.adminSubjectAccessReviews.Create(r.Context(), &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				User:   user.Username,
+				UID:    user.UID,
+				Groups: user.Groups,
+				Extra:  extra,
+				NonResourceAttributes: &authorizationv1.NonResourceAttributes{
+					Path: r.URL.Path,
+					Verb: strings.ToLower(r.Method),
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			m.log.Error(err, "failed to review access to the metrics admin endpoints", "user", user.Username)
+			http.Error(w, "failed to authorize", http.StatusInternalServerError)
+			return
+		}
+
+		if !review.Status.Allowed {
+			m.log.Info("refused request to the metrics admin endpoints", "user", user.Username, "path", r.URL.Path)
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
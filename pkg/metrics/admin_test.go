@@ -0,0 +1,131 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/utils/clock"
+)
+
+func TestAdminHandler(t *testing.T) {
+	// The fake API server authenticates the `secret` token as the admin user
+	// and the `viewer` token as the viewer user, and only authorizes the
+	// admin user to use the admin endpoints.
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action coretesting.Action) (bool, runtime.Object, error) {
+		review := action.(coretesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		switch review.Spec.Token {
+		case "secret":
+			review.Status.Authenticated = true
+			review.Status.User.Username = "admin"
+		case "viewer":
+			review.Status.Authenticated = true
+			review.Status.User.Username = "viewer"
+		}
+		return true, review, nil
+	})
+	client.PrependReactor("create", "subjectaccessreviews", func(action coretesting.Action) (bool, runtime.Object, error) {
+		review := action.(coretesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attrs := review.Spec.NonResourceAttributes
+		review.Status.Allowed = review.Spec.User == "admin" && attrs != nil && strings.HasPrefix(attrs.Path, adminMetricsPath)
+		return true, review, nil
+	})
+
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{},
+		WithAdminEndpoints(client.AuthorizationV1().SubjectAccessReviews()),
+		WithTokenReviewAuthentication(TokenReviewAuthentication{TokenReviews: client.AuthenticationV1().TokenReviews()}),
+	)
+	// Metrics are registered when the metrics handler is first created.
+	m.Handler()
+	handler := m.authenticate(m.authorizeAdmin(m.adminHandler()))
+
+	const metricName = "certmanager_controller_sync_call_count"
+	m.IncrementSyncCallCount("issuers")
+
+	do := func(method, path, token string) int {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	exposed := func() int {
+		count, err := testutil.GatherAndCount(m.registry, metricName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	tests := []struct {
+		name, method, path, token string
+		expCode                   int
+		expExposed                int
+	}{
+		{"missing token", http.MethodPost, adminMetricsPath + metricName + "/disable", "", http.StatusUnauthorized, 1},
+		{"wrong token", http.MethodPost, adminMetricsPath + metricName + "/disable", "wrong", http.StatusUnauthorized, 1},
+		{"not authorized", http.MethodPost, adminMetricsPath + metricName + "/disable", "viewer", http.StatusForbidden, 1},
+		{"wrong method", http.MethodGet, adminMetricsPath + metricName + "/disable", "secret", http.StatusMethodNotAllowed, 1},
+		{"unknown metric", http.MethodPost, adminMetricsPath + "certmanager_unknown/disable", "secret", http.StatusNotFound, 1},
+		{"unknown action", http.MethodPost, adminMetricsPath + metricName + "/reset", "secret", http.StatusNotFound, 1},
+		{"disable", http.MethodPost, adminMetricsPath + metricName + "/disable", "secret", http.StatusNoContent, 0},
+		{"disable again", http.MethodPost, adminMetricsPath + metricName + "/disable", "secret", http.StatusNoContent, 0},
+		{"enable", http.MethodPost, adminMetricsPath + metricName + "/enable", "secret", http.StatusNoContent, 1},
+		{"enable again", http.MethodPost, adminMetricsPath + metricName + "/enable", "secret", http.StatusNoContent, 1},
+	}
+	for _, test := range tests {
+		if code := do(test.method, test.path, test.token); code != test.expCode {
+			t.Errorf("%s: expected status %d, got %d", test.name, test.expCode, code)
+		}
+		if count := exposed(); count != test.expExposed {
+			t.Errorf("%s: expected %d series exposed, got %d", test.name, test.expExposed, count)
+		}
+	}
+}
+
+func TestAdminEndpointsRequireAuthentication(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{}, WithAdminEndpoints(client.AuthorizationV1().SubjectAccessReviews()))
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	server := m.NewServer(ln)
+
+	req := httptest.NewRequest(http.MethodPost, adminMetricsPath+"certmanager_controller_sync_call_count/disable", nil)
+	rec := httptest.NewRecorder()
+	server.Handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected the admin endpoints not to be served without authentication, got status %d", rec.Code)
+	}
+}
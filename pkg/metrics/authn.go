@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authenticationv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+)
+
+// defaultTokenHeaderName is the request header which carries the bearer
+// token when TokenReviewAuthentication.HeaderName is not set.
+const defaultTokenHeaderName = "Authorization"
+
+// TokenReviewAuthentication configures authentication of scrapes of the
+// metrics endpoint using the Kubernetes TokenReview API.
+type TokenReviewAuthentication struct {
+	// TokenReviews is used to review the bearer token of each scrape.
+	TokenReviews authenticationv1client.TokenReviewInterface
+
+	// HeaderName is the request header which carries the bearer token, with
+	// an optional `Bearer ` prefix. Defaults to Authorization.
+	HeaderName string
+
+	// Audiences are the audiences the token must be valid for. If empty, the
+	// audiences of the API server are used.
+	Audiences []string
+}
+
+// authenticatedUserKey is the request context key of the user which
+// authenticate authenticated the request as.
+type authenticatedUserKey struct{}
+
+// authenticatedUser returns the user which authenticate authenticated the
+// request as, if any.
+func authenticatedUser(ctx context.Context) (authenticationv1.UserInfo, bool) {
+	user, ok := ctx.Value(authenticatedUserKey{}).(authenticationv1.UserInfo)
+	return user, ok
+}
+
+// authenticate returns an HTTP handler which only passes scrapes to next if
+// they present a bearer token which the API server authenticates. Scrapes
+// with a missing or invalid token are refused with 401 Unauthorized. The
+// authenticated user is passed to next in the request context. If no
+// TokenReviewAuthentication is configured, next is returned unchanged.
+func (m *Metrics) authenticate(next http.Handler) http.Handler {
+	authn := m.opts.tokenReviewAuthentication
+	if authn == nil {
+		return next
+	}
+
+	headerName := authn.HeaderName
+	if headerName == "" {
+		headerName = defaultTokenHeaderName
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimSpace(r.Header.Get(headerName))
+		if len(token) > len("Bearer ") && strings.EqualFold(token[:len("Bearer ")], "Bearer ") {
+			token = strings.TrimSpace(token[len("Bearer "):])
+		}
+		if token == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		review, err := authn.TokenReviews.Create(r.Context(), &authenticationv1.TokenReview{
+			Spec: authenticationv1.TokenReviewSpec{
+				Token:     token,
+				Audiences: authn.Audiences,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			m.log.Error(err, "failed to review the bearer token of a metrics scrape")
+			http.Error(w, "failed to authenticate", http.StatusInternalServerError)
+			return
+		}
+
+		if !review.Status.Authenticated || !audiencesIntersect(authn.Audiences, review.Status.Audiences) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), authenticatedUserKey{}, review.Status.User)))
+	})
+}
+
+// audiencesIntersect returns true if the token was authenticated for at least
+// one of the requested audiences, or if no audiences were requested.
+func audiencesIntersect(requested, authenticated []string) bool {
+	if len(requested) == 0 {
+		return true
+	}
+	for _, r := range requested {
+		for _, a := range authenticated {
+			if r == a {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/utils/clock"
+)
+
+func TestTokenReviewAuthentication(t *testing.T) {
+	// The fake API server authenticates `valid` for the `metrics` audience,
+	// `other-audience` for a different audience, and fails to review
+	// `review-error`. All other tokens, e.g. `expired`, are not
+	// authenticated.
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action coretesting.Action) (bool, runtime.Object, error) {
+		review := action.(coretesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		switch review.Spec.Token {
+		case "valid":
+			review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, Audiences: []string{"metrics"}}
+		case "other-audience":
+			review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, Audiences: []string{"other"}}
+		case "review-error":
+			return true, nil, errors.New("the server is currently unable to handle the request")
+		default:
+			review.Status = authenticationv1.TokenReviewStatus{Error: "token has expired"}
+		}
+		return true, review, nil
+	})
+
+	tests := map[string]struct {
+		authn  *TokenReviewAuthentication
+		header string
+		value  string
+		exp    int
+	}{
+		"anonymous scrapes are served if authentication is not configured": {
+			exp: http.StatusOK,
+		},
+		"anonymous scrapes are refused": {
+			authn: &TokenReviewAuthentication{Audiences: []string{"metrics"}},
+			exp:   http.StatusUnauthorized,
+		},
+		"valid token": {
+			authn: &TokenReviewAuthentication{Audiences: []string{"metrics"}},
+			value: "Bearer valid",
+			exp:   http.StatusOK,
+		},
+		"valid token without requested audiences": {
+			authn: &TokenReviewAuthentication{},
+			value: "Bearer valid",
+			exp:   http.StatusOK,
+		},
+		"expired token": {
+			authn: &TokenReviewAuthentication{Audiences: []string{"metrics"}},
+			value: "Bearer expired",
+			exp:   http.StatusUnauthorized,
+		},
+		"token for another audience": {
+			authn: &TokenReviewAuthentication{Audiences: []string{"metrics"}},
+			value: "Bearer other-audience",
+			exp:   http.StatusUnauthorized,
+		},
+		"valid token in a custom header": {
+			authn:  &TokenReviewAuthentication{HeaderName: "X-Metrics-Token", Audiences: []string{"metrics"}},
+			header: "X-Metrics-Token",
+			value:  "valid",
+			exp:    http.StatusOK,
+		},
+		"token in the wrong header": {
+			authn: &TokenReviewAuthentication{HeaderName: "X-Metrics-Token", Audiences: []string{"metrics"}},
+			value: "Bearer valid",
+			exp:   http.StatusUnauthorized,
+		},
+		"token review fails": {
+			authn: &TokenReviewAuthentication{Audiences: []string{"metrics"}},
+			value: "Bearer review-error",
+			exp:   http.StatusInternalServerError,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var opts []Option
+			if test.authn != nil {
+				authn := *test.authn
+				authn.TokenReviews = client.AuthenticationV1().TokenReviews()
+				opts = append(opts, WithTokenReviewAuthentication(authn))
+			}
+			m := New(logtesting.NewTestLogger(t), clock.RealClock{}, opts...)
+
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer ln.Close()
+			server := m.NewServer(ln)
+
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if test.value != "" {
+				header := test.header
+				if header == "" {
+					header = "Authorization"
+				}
+				req.Header.Set(header, test.value)
+			}
+			rec := httptest.NewRecorder()
+			server.Handler.ServeHTTP(rec, req)
+
+			if rec.Code != test.exp {
+				t.Errorf("expected status %d, got %d", test.exp, rec.Code)
+			}
+		})
+	}
+}
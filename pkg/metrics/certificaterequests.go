@@ -0,0 +1,164 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+const (
+	// PolicyDecisionAllowed is the decision label value used when a policy
+	// approves a CertificateRequest.
+	PolicyDecisionAllowed = "allowed"
+
+	// PolicyDecisionDenied is the decision label value used when a policy
+	// denies a CertificateRequest.
+	PolicyDecisionDenied = "denied"
+
+	// ApprovalSourceInternal is the approval_source label value used for
+	// decisions made by cert-manager's internal approver.
+	ApprovalSourceInternal = "internal"
+
+	// ApprovalSourcePolicy is the approval_source label value used for
+	// decisions made by an external policy approver.
+	ApprovalSourcePolicy = "policy"
+
+	// ApprovalSourceManual is the approval_source label value used for
+	// decisions made manually, e.g. using `cmctl approve`.
+	ApprovalSourceManual = "manual"
+
+	// CSRSourceController is the csr_source label value used for
+	// certificates issued for a CSR generated by cert-manager for a
+	// Certificate.
+	CSRSourceController = "controller"
+
+	// CSRSourceClient is the csr_source label value used for certificates
+	// issued for a CSR provided by a client in a CertificateRequest which is
+	// not managed by a Certificate.
+	CSRSourceClient = "client"
+)
+
+// IncrementCertificateRequestPolicyDecision increases the count of approval
+// decisions made by the given policy from the given approval source.
+func (m *Metrics) IncrementCertificateRequestPolicyDecision(policy, decision, source string) {
+	m.certificateRequestPolicyDecisionCount.WithLabelValues(policy, decision, source).Inc()
+}
+
+// ObserveCertificateRequestApprovalDuration observes the time between a
+// CertificateRequest for the given issuer being created and being approved.
+func (m *Metrics) ObserveCertificateRequestApprovalDuration(ref cmmeta.ObjectReference, duration time.Duration) {
+	m.certificateRequestApprovalDurationSeconds.WithLabelValues(ref.Kind, ref.Group).Observe(duration.Seconds())
+}
+
+// updateCertificateRequestRequestorCount counts the CertificateRequests
+// created by each requestor.
+func (m *Metrics) updateCertificateRequestRequestorCount(reqs []*cmapi.CertificateRequest) {
+	values := newGaugeValues()
+	for _, req := range reqs {
+		values.inc(normalizeRequestor(req.Spec.Username))
+	}
+
+	m.setGaugeValues(m.certificateRequestRequestorCount, values)
+}
+
+// updateCertificateNeedsInterventionCount counts the Certificates which are
+// not Ready and whose CertificateRequest for the next revision has been
+// denied, is invalid, or was permanently rejected by the issuer. These
+// Certificates will not be issued until the cause is fixed by a human.
+func (m *Metrics) updateCertificateNeedsInterventionCount(crts []*cmapi.Certificate, reqs []*cmapi.CertificateRequest) {
+	values := newGaugeValues()
+
+	type revisionKey struct {
+		certificate types.NamespacedName
+		revision    string
+	}
+	reqsByRevision := make(map[revisionKey]*cmapi.CertificateRequest, len(reqs))
+	for _, req := range reqs {
+		name, ok := req.Annotations[cmapi.CertificateNameKey]
+		if !ok {
+			continue
+		}
+		reqsByRevision[revisionKey{
+			certificate: types.NamespacedName{Namespace: req.Namespace, Name: name},
+			revision:    req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey],
+		}] = req
+	}
+
+	for _, crt := range crts {
+		if apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}) {
+			continue
+		}
+
+		nextRevision := 1
+		if crt.Status.Revision != nil {
+			nextRevision = *crt.Status.Revision + 1
+		}
+		req, ok := reqsByRevision[revisionKey{
+			certificate: types.NamespacedName{Namespace: crt.Namespace, Name: crt.Name},
+			revision:    strconv.Itoa(nextRevision),
+		}]
+		if !ok {
+			continue
+		}
+
+		if reason := interventionReason(req); reason != "" {
+			values.inc(reason, crt.Spec.IssuerRef.Kind)
+		}
+	}
+
+	m.setGaugeValues(m.certificateNeedsInterventionCount, values)
+}
+
+// interventionReason returns the reason the CertificateRequest is in a
+// terminal state which needs intervention, or an empty string if it is not.
+func interventionReason(req *cmapi.CertificateRequest) string {
+	switch {
+	case apiutil.CertificateRequestIsDenied(req):
+		return cmapi.CertificateRequestReasonDenied
+	case apiutil.CertificateRequestHasInvalidRequest(req):
+		return string(cmapi.CertificateRequestConditionInvalidRequest)
+	case apiutil.CertificateRequestReadyReason(req) == cmapi.CertificateRequestReasonFailed:
+		return cmapi.CertificateRequestReasonFailed
+	default:
+		return ""
+	}
+}
+
+// normalizeRequestor reduces the username of a CertificateRequest's creator
+// to limit the number of series. ServiceAccounts are reported by namespace,
+// e.g. `serviceaccount:cert-manager`, and all other users as `user`.
+// CertificateRequests without a username are reported as `unknown`.
+func normalizeRequestor(username string) string {
+	if username == "" {
+		return "unknown"
+	}
+	if namespace, _, err := serviceaccount.SplitUsername(username); err == nil {
+		return "serviceaccount:" + namespace
+	}
+	return "user"
+}
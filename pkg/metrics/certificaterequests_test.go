@@ -0,0 +1,204 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+const requestorMetadata = `
+	# HELP certmanager_certificaterequest_requestor_count The number of CertificateRequests by the identity which created them. ServiceAccounts are grouped by namespace, and all other users are grouped together.
+	# TYPE certmanager_certificaterequest_requestor_count gauge
+`
+
+func TestResyncCertificateRequestRequestorCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	reqBy := func(name, username string) *cmapi.CertificateRequest {
+		return gen.CertificateRequest(name,
+			gen.SetCertificateRequestNamespace("test-ns"),
+			gen.SetCertificateRequestUsername(username),
+		)
+	}
+
+	m.Resync(ResyncState{CertificateRequests: []*cmapi.CertificateRequest{
+		reqBy("cr1", "system:serviceaccount:cert-manager:cert-manager"),
+		reqBy("cr2", "system:serviceaccount:cert-manager:cert-manager"),
+		reqBy("cr3", "system:serviceaccount:team-a:builder"),
+		reqBy("cr4", "alice@example.com"),
+		reqBy("cr5", "kubernetes-admin"),
+		reqBy("cr6", ""),
+	}})
+	if err := testutil.CollectAndCompare(m.certificateRequestRequestorCount,
+		strings.NewReader(requestorMetadata+`
+	certmanager_certificaterequest_requestor_count{requestor="serviceaccount:cert-manager"} 2
+	certmanager_certificaterequest_requestor_count{requestor="serviceaccount:team-a"} 1
+	certmanager_certificaterequest_requestor_count{requestor="unknown"} 1
+	certmanager_certificaterequest_requestor_count{requestor="user"} 2
+`),
+		"certmanager_certificaterequest_requestor_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestResyncCertificateRequestRequestorCountWhileScraped(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+	// Metrics are only exposed in a snapshot once registered.
+	m.Handler()
+
+	reqBy := func(name, username string) *cmapi.CertificateRequest {
+		return gen.CertificateRequest(name,
+			gen.SetCertificateRequestNamespace("test-ns"),
+			gen.SetCertificateRequestUsername(username),
+		)
+	}
+	// Both states contain the same CertificateRequests created by users, and
+	// differ only in one other CertificateRequest.
+	var shared []*cmapi.CertificateRequest
+	for i := 0; i < 1000; i++ {
+		shared = append(shared, reqBy(fmt.Sprintf("user-%d", i), "alice@example.com"))
+	}
+	first := ResyncState{CertificateRequests: append([]*cmapi.CertificateRequest{reqBy("sa", "system:serviceaccount:team-a:builder")}, shared...)}
+	second := ResyncState{CertificateRequests: append([]*cmapi.CertificateRequest{reqBy("unknown", "")}, shared...)}
+
+	const userSeries = `certmanager_certificaterequest_requestor_count{requestor="user"}`
+	m.Resync(first)
+
+	// Scrapes made while resyncing must never observe the overlapping series
+	// missing or partially recomputed.
+	done := make(chan struct{})
+	resynced := make(chan struct{})
+	go func() {
+		defer close(resynced)
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if i%2 == 0 {
+				m.Resync(second)
+			} else {
+				m.Resync(first)
+			}
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		if v := m.Snapshot()[userSeries]; v != 1000 {
+			t.Errorf("expected %s to be 1000 across resyncs, observed %v", userSeries, v)
+			break
+		}
+	}
+	close(done)
+	<-resynced
+
+	m.Resync(second)
+	if err := testutil.CollectAndCompare(m.certificateRequestRequestorCount,
+		strings.NewReader(requestorMetadata+`
+	certmanager_certificaterequest_requestor_count{requestor="unknown"} 1
+	certmanager_certificaterequest_requestor_count{requestor="user"} 1000
+`),
+		"certmanager_certificaterequest_requestor_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const needsInterventionMetadata = `
+	# HELP certmanager_certificate_needs_intervention_count The number of Certificates which are not Ready and whose latest CertificateRequest is in a terminal state that will not resolve without intervention, by reason and issuer kind.
+	# TYPE certmanager_certificate_needs_intervention_count gauge
+`
+
+func TestResyncCertificateNeedsInterventionCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crt := func(name, kind string, mods ...gen.CertificateModifier) *cmapi.Certificate {
+		mods = append(mods,
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: kind}),
+		)
+		return gen.Certificate(name, mods...)
+	}
+	req := func(crtName, revision string, condition cmapi.CertificateRequestCondition) *cmapi.CertificateRequest {
+		return gen.CertificateRequest(crtName+"-"+revision,
+			gen.SetCertificateRequestNamespace("test-ns"),
+			gen.SetCertificateRequestAnnotations(map[string]string{
+				cmapi.CertificateNameKey:                      crtName,
+				cmapi.CertificateRequestRevisionAnnotationKey: revision,
+			}),
+			gen.SetCertificateRequestStatusCondition(condition),
+		)
+	}
+	ready := func(status cmmeta.ConditionStatus, reason string) cmapi.CertificateRequestCondition {
+		return cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: status, Reason: reason}
+	}
+	denied := cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionDenied, Status: cmmeta.ConditionTrue}
+	invalid := cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionInvalidRequest, Status: cmmeta.ConditionTrue}
+	crtReady := gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue})
+
+	m.Resync(ResyncState{
+		Certificates: []*cmapi.Certificate{
+			crt("denied", "Issuer"),
+			crt("invalid", "Issuer"),
+			crt("failed", "ClusterIssuer", gen.SetCertificateRevision(1)),
+			// Only the CertificateRequest for the next revision is
+			// considered.
+			crt("failed-previously", "Issuer", gen.SetCertificateRevision(2)),
+			crt("pending", "Issuer"),
+			// Ready Certificates are still usable.
+			crt("ready", "Issuer", crtReady),
+		},
+		CertificateRequests: []*cmapi.CertificateRequest{
+			req("denied", "1", denied),
+			req("invalid", "1", invalid),
+			req("failed", "2", ready(cmmeta.ConditionFalse, cmapi.CertificateRequestReasonFailed)),
+			req("failed-previously", "2", ready(cmmeta.ConditionFalse, cmapi.CertificateRequestReasonFailed)),
+			req("failed-previously", "3", ready(cmmeta.ConditionFalse, cmapi.CertificateRequestReasonPending)),
+			req("pending", "1", ready(cmmeta.ConditionFalse, cmapi.CertificateRequestReasonPending)),
+			req("ready", "1", denied),
+		},
+	})
+	if err := testutil.CollectAndCompare(m.certificateNeedsInterventionCount,
+		strings.NewReader(needsInterventionMetadata+`
+	certmanager_certificate_needs_intervention_count{issuer_kind="ClusterIssuer",reason="Failed"} 1
+	certmanager_certificate_needs_intervention_count{issuer_kind="Issuer",reason="Denied"} 1
+	certmanager_certificate_needs_intervention_count{issuer_kind="Issuer",reason="InvalidRequest"} 1
+`),
+		"certmanager_certificate_needs_intervention_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	m.Resync(ResyncState{})
+	if err := testutil.CollectAndCompare(m.certificateNeedsInterventionCount,
+		strings.NewReader(""),
+		"certmanager_certificate_needs_intervention_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -18,15 +18,29 @@ package metrics
 
 import (
 	"context"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/tools/cache"
 
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
+const (
+	// pendingPhaseInitial is the phase label value of Certificates which
+	// have never been issued.
+	pendingPhaseInitial = "initial"
+
+	// pendingPhaseRenewal is the phase label value of Certificates which
+	// are being reissued.
+	pendingPhaseRenewal = "renewal"
+)
+
 // UpdateCertificate will update the given Certificate's metrics for its expiry, renewal, and status
 // condition.
 func (m *Metrics) UpdateCertificate(ctx context.Context, crt *cmapi.Certificate) {
@@ -51,11 +65,12 @@ func (m *Metrics) updateCertificateExpiry(ctx context.Context, key string, crt *
 	}
 
 	m.certificateExpiryTimeSeconds.With(prometheus.Labels{
-		"name":         crt.Name,
-		"namespace":    crt.Namespace,
-		"issuer_name":  crt.Spec.IssuerRef.Name,
-		"issuer_kind":  crt.Spec.IssuerRef.Kind,
-		"issuer_group": crt.Spec.IssuerRef.Group}).Set(expiryTime)
+		"name":             crt.Name,
+		"namespace":        crt.Namespace,
+		"issuer_name":      crt.Spec.IssuerRef.Name,
+		"issuer_kind":      crt.Spec.IssuerRef.Kind,
+		"issuer_group":     crt.Spec.IssuerRef.Group,
+		"issuer_namespace": issuerNamespace(crt)}).Set(expiryTime)
 }
 
 // updateCertificateRenewalTime updates the renew before duration of a certificate
@@ -67,11 +82,12 @@ func (m *Metrics) updateCertificateRenewalTime(crt *cmapi.Certificate) {
 	}
 
 	m.certificateRenewalTimeSeconds.With(prometheus.Labels{
-		"name":         crt.Name,
-		"namespace":    crt.Namespace,
-		"issuer_name":  crt.Spec.IssuerRef.Name,
-		"issuer_kind":  crt.Spec.IssuerRef.Kind,
-		"issuer_group": crt.Spec.IssuerRef.Group}).Set(renewalTime)
+		"name":             crt.Name,
+		"namespace":        crt.Namespace,
+		"issuer_name":      crt.Spec.IssuerRef.Name,
+		"issuer_kind":      crt.Spec.IssuerRef.Kind,
+		"issuer_group":     crt.Spec.IssuerRef.Group,
+		"issuer_namespace": issuerNamespace(crt)}).Set(renewalTime)
 
 }
 
@@ -79,16 +95,22 @@ func (m *Metrics) updateCertificateRenewalTime(crt *cmapi.Certificate) {
 func (m *Metrics) updateCertificateStatus(key string, crt *cmapi.Certificate) {
 	for _, c := range crt.Status.Conditions {
 		if c.Type == cmapi.CertificateConditionReady {
-			m.updateCertificateReadyStatus(crt, c.Status)
+			m.updateCertificateReadyStatus(crt, c.Status, c.Reason)
 			return
 		}
 	}
 
 	// If no status condition set yet, set to Unknown
-	m.updateCertificateReadyStatus(crt, cmmeta.ConditionUnknown)
+	m.updateCertificateReadyStatus(crt, cmmeta.ConditionUnknown, "")
 }
 
-func (m *Metrics) updateCertificateReadyStatus(crt *cmapi.Certificate, current cmmeta.ConditionStatus) {
+func (m *Metrics) updateCertificateReadyStatus(crt *cmapi.Certificate, current cmmeta.ConditionStatus, reason string) {
+	if m.opts.certificateReadyStatusReason {
+		// The reason may have changed since the last update, so remove the
+		// existing series to avoid exposing a stale reason.
+		m.certificateReadyStatus.DeletePartialMatch(prometheus.Labels{"name": crt.Name, "namespace": crt.Namespace})
+	}
+
 	for _, condition := range readyConditionStatuses {
 		value := 0.0
 
@@ -96,17 +118,99 @@ func (m *Metrics) updateCertificateReadyStatus(crt *cmapi.Certificate, current c
 			value = 1.0
 		}
 
-		m.certificateReadyStatus.With(prometheus.Labels{
-			"name":         crt.Name,
-			"namespace":    crt.Namespace,
-			"condition":    string(condition),
-			"issuer_name":  crt.Spec.IssuerRef.Name,
-			"issuer_kind":  crt.Spec.IssuerRef.Kind,
-			"issuer_group": crt.Spec.IssuerRef.Group,
-		}).Set(value)
+		labels := prometheus.Labels{
+			"name":             crt.Name,
+			"namespace":        crt.Namespace,
+			"condition":        string(condition),
+			"issuer_name":      crt.Spec.IssuerRef.Name,
+			"issuer_kind":      crt.Spec.IssuerRef.Kind,
+			"issuer_group":     crt.Spec.IssuerRef.Group,
+			"issuer_namespace": issuerNamespace(crt),
+		}
+		if m.opts.certificateReadyStatusReason {
+			labels["reason"] = reason
+		}
+
+		m.certificateReadyStatus.With(labels).Set(value)
 	}
 }
 
+// issuerNamespace returns the namespace of the issuer referenced by the
+// Certificate. ClusterIssuers are not namespaced, so an empty string is
+// returned for them. All other issuers, including external issuers, are
+// assumed to be namespaced issuers in the Certificate's namespace.
+func issuerNamespace(crt *cmapi.Certificate) string {
+	ref := crt.Spec.IssuerRef
+	if ref.Kind == cmapi.ClusterIssuerKind && (ref.Group == "" || ref.Group == certmanager.GroupName) {
+		return ""
+	}
+	return crt.Namespace
+}
+
+// ObserveCertificateIssuanceDuration records the time taken for a Certificate
+// referencing the given issuer to first become Ready after it was created.
+func (m *Metrics) ObserveCertificateIssuanceDuration(issuerName, issuerKind, issuerGroup string, d time.Duration) {
+	m.certificateIssuanceDurationSeconds.WithLabelValues(issuerName, issuerKind, issuerGroup).Observe(d.Seconds())
+}
+
+// IncrementCertificateManualRenewal increases the count of Certificate
+// re-issuances in the given namespace which were manually triggered by an
+// operator.
+func (m *Metrics) IncrementCertificateManualRenewal(namespace string) {
+	m.certificateManualRenewalCount.WithLabelValues(namespace).Inc()
+}
+
+// IncrementCertificateReconcileError increases the count of errors
+// encountered while reconciling the given Certificate. The reason is the
+// Kubernetes API status reason of the error, or `Unknown` for errors which
+// did not come from the API server.
+func (m *Metrics) IncrementCertificateReconcileError(crt *cmapi.Certificate, err error) {
+	reason := string(apierrors.ReasonForError(err))
+	if reason == "" {
+		reason = "Unknown"
+	}
+
+	m.certificateReconcileErrorCount.WithLabelValues(
+		crt.Spec.IssuerRef.Name,
+		crt.Spec.IssuerRef.Kind,
+		crt.Spec.IssuerRef.Group,
+		reason,
+	).Inc()
+}
+
+// IncrementCertificateIssued increases the count of certificates successfully
+// issued by the referenced issuer for a CSR from the given source. It should
+// be called once for each issuance, including renewals.
+func (m *Metrics) IncrementCertificateIssued(ref cmmeta.ObjectReference, csrSource string) {
+	m.certificateIssuedCount.WithLabelValues(ref.Kind, ref.Group, csrSource).Inc()
+	m.notifyIssuance(IssuanceEvent{
+		Metric: m.opts.namespace + "_certificate_issued_count",
+		Labels: map[string]string{"issuer_kind": ref.Kind, "issuer_group": ref.Group, "csr_source": csrSource},
+		Value:  1,
+	})
+}
+
+// IncrementCertificateRenewalIdentical increases the count of issuances
+// which produced a certificate identical to the one issued for the previous
+// revision.
+func (m *Metrics) IncrementCertificateRenewalIdentical(issuerKind string) {
+	m.certificateRenewalIdenticalCount.WithLabelValues(issuerKind).Inc()
+}
+
+// IncrementCertificateRenewalReschedule increases the count of Certificate
+// renewals which were rescheduled without a new certificate being issued, or
+// which were moved earlier.
+func (m *Metrics) IncrementCertificateRenewalReschedule(issuerKind string) {
+	m.certificateRenewalRescheduleCount.WithLabelValues(issuerKind).Inc()
+}
+
+// IncrementCertificateTriggeredRequest increases the count of
+// CertificateRequests created while reconciling a Certificate which
+// references an issuer of the given kind.
+func (m *Metrics) IncrementCertificateTriggeredRequest(issuerKind string) {
+	m.certificateTriggeredRequestCount.WithLabelValues(issuerKind).Inc()
+}
+
 // RemoveCertificate will delete the Certificate metrics from continuing to be
 // exposed.
 func (m *Metrics) RemoveCertificate(key string) {
@@ -120,3 +224,280 @@ func (m *Metrics) RemoveCertificate(key string) {
 	m.certificateRenewalTimeSeconds.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
 	m.certificateReadyStatus.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
 }
+
+// updateCertificateEmptyIssuerGroupCount counts the Certificates in each
+// namespace which do not set an issuerRef group.
+func (m *Metrics) updateCertificateEmptyIssuerGroupCount(crts []*cmapi.Certificate) {
+	values := newGaugeValues()
+
+	for _, crt := range crts {
+		if crt.Spec.IssuerRef.Group == "" {
+			values.inc(crt.Namespace)
+		}
+	}
+
+	m.setGaugeValues(m.certificateEmptyIssuerGroupCount, values)
+}
+
+// updateCertificateUpcomingRenewals counts the Certificates whose renewal time
+// falls within each of the upcoming renewal windows. Certificates whose
+// renewal time has already passed are overdue, so are counted in every
+// window as well as in the overdue series.
+func (m *Metrics) updateCertificateUpcomingRenewals(crts []*cmapi.Certificate) {
+	values := newGaugeValues()
+
+	now := m.clock.Now()
+	overdue := 0
+	for _, crt := range crts {
+		if crt.Status.RenewalTime != nil && crt.Status.RenewalTime.Time.Before(now) {
+			overdue++
+		}
+	}
+	values.set(float64(overdue), overdueRenewalWindow)
+
+	for _, window := range upcomingRenewalWindows {
+		count := 0
+		for _, crt := range crts {
+			if crt.Status.RenewalTime == nil {
+				continue
+			}
+			if crt.Status.RenewalTime.Time.Before(now.Add(window.duration)) {
+				count++
+			}
+		}
+		values.set(float64(count), window.label)
+	}
+
+	m.setGaugeValues(m.certificateUpcomingRenewals, values)
+}
+
+// updateCertificateTimeToExpiry counts the Certificates which expire within
+// each bucket of timeToExpiryBuckets. Certificates which have not yet been
+// issued are ignored, and those which have already expired have a negative
+// time to expiry.
+func (m *Metrics) updateCertificateTimeToExpiry(crts []*cmapi.Certificate) {
+	values := newGaugeValues()
+
+	now := m.clock.Now()
+	for _, crt := range crts {
+		if crt.Status.NotAfter == nil {
+			continue
+		}
+		values.observe(timeToExpiryBuckets, crt.Status.NotAfter.Sub(now).Seconds(), crt.Spec.IssuerRef.Kind)
+	}
+
+	m.setGaugeValues(m.certificateTimeToExpiryBucketCount, values)
+}
+
+// updateCertificateAge counts the Ready Certificates which became valid
+// within each bucket of timeToExpiryBuckets. Certificates which are not
+// Ready, or have not yet been issued, are ignored.
+func (m *Metrics) updateCertificateAge(crts []*cmapi.Certificate) {
+	values := newGaugeValues()
+
+	now := m.clock.Now()
+	for _, crt := range crts {
+		if crt.Status.NotBefore == nil {
+			continue
+		}
+		if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}) {
+			continue
+		}
+		values.observe(timeToExpiryBuckets, now.Sub(crt.Status.NotBefore.Time).Seconds(), crt.Spec.IssuerRef.Kind)
+	}
+
+	m.setGaugeValues(m.certificateAgeBucketCount, values)
+}
+
+// updateCertificateExternalIssuerCount counts the Certificates which reference
+// an issuer outside of the cert-manager.io group. An empty group defaults to
+// cert-manager.io.
+func (m *Metrics) updateCertificateExternalIssuerCount(crts []*cmapi.Certificate) {
+	values := newGaugeValues()
+
+	for _, crt := range crts {
+		group := crt.Spec.IssuerRef.Group
+		if group == "" || group == certmanager.GroupName {
+			continue
+		}
+		values.inc(group, crt.Spec.IssuerRef.Kind)
+	}
+
+	m.setGaugeValues(m.certificateExternalIssuerCount, values)
+}
+
+// SetCertificatesInBackoff sets the number of Certificates which are still
+// within the backoff period following their last failed issuance attempt, by
+// the kind and group of their issuer. The backoff period is computed by the
+// caller, since it is defined by the certificates controllers.
+func (m *Metrics) SetCertificatesInBackoff(crts []*cmapi.Certificate) {
+	values := newGaugeValues()
+	for _, crt := range crts {
+		values.inc(crt.Spec.IssuerRef.Kind, crt.Spec.IssuerRef.Group)
+	}
+
+	m.setGaugeValues(m.certificateInBackoffCount, values)
+}
+
+// updateCertificatePendingCount counts the Certificates pending issuance.
+// Certificates which have never been issued are pending initial issuance,
+// whether or not an issuance is in progress. Certificates which have been
+// issued before are pending renewal while their Issuing condition is True.
+func (m *Metrics) updateCertificatePendingCount(crts []*cmapi.Certificate) {
+	values := newGaugeValues()
+
+	for _, crt := range crts {
+		phase := pendingPhaseInitial
+		if crt.Status.Revision != nil {
+			if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+				Type:   cmapi.CertificateConditionIssuing,
+				Status: cmmeta.ConditionTrue,
+			}) {
+				continue
+			}
+			phase = pendingPhaseRenewal
+		}
+		values.inc(phase, crt.Spec.IssuerRef.Kind)
+	}
+
+	m.setGaugeValues(m.certificatePendingCount, values)
+}
+
+// updateCertificateSANTypeCount sums the subject alternative names of each
+// type requested by the Certificates. All SAN types are reported, including
+// those with no SANs.
+func (m *Metrics) updateCertificateSANTypeCount(crts []*cmapi.Certificate) {
+	values := newGaugeValues()
+
+	var dns, ip, uri, email int
+	for _, crt := range crts {
+		dns += len(crt.Spec.DNSNames)
+		ip += len(crt.Spec.IPAddresses)
+		uri += len(crt.Spec.URIs)
+		email += len(crt.Spec.EmailAddresses)
+	}
+
+	values.set(float64(dns), "dns")
+	values.set(float64(ip), "ip")
+	values.set(float64(uri), "uri")
+	values.set(float64(email), "email")
+
+	m.setGaugeValues(m.certificateSANTypeCount, values)
+}
+
+// updateCertificateSubjectFieldCount counts the Certificates which set each
+// subject field other than the common name. A literalSubject may set any
+// subject field, so it is reported separately. All fields are reported,
+// including those which no Certificate sets.
+func (m *Metrics) updateCertificateSubjectFieldCount(crts []*cmapi.Certificate) {
+	values := newGaugeValues()
+
+	var organization, country, organizationalUnit, locality, province, streetAddress, postalCode, serialNumber, literalSubject int
+	for _, crt := range crts {
+		if crt.Spec.LiteralSubject != "" {
+			literalSubject++
+		}
+
+		subject := crt.Spec.Subject
+		if subject == nil {
+			continue
+		}
+		if len(subject.Organizations) > 0 {
+			organization++
+		}
+		if len(subject.Countries) > 0 {
+			country++
+		}
+		if len(subject.OrganizationalUnits) > 0 {
+			organizationalUnit++
+		}
+		if len(subject.Localities) > 0 {
+			locality++
+		}
+		if len(subject.Provinces) > 0 {
+			province++
+		}
+		if len(subject.StreetAddresses) > 0 {
+			streetAddress++
+		}
+		if len(subject.PostalCodes) > 0 {
+			postalCode++
+		}
+		if subject.SerialNumber != "" {
+			serialNumber++
+		}
+	}
+
+	values.set(float64(organization), "organization")
+	values.set(float64(country), "country")
+	values.set(float64(organizationalUnit), "organizational_unit")
+	values.set(float64(locality), "locality")
+	values.set(float64(province), "province")
+	values.set(float64(streetAddress), "street_address")
+	values.set(float64(postalCode), "postal_code")
+	values.set(float64(serialNumber), "serial_number")
+	values.set(float64(literalSubject), "literal_subject")
+
+	m.setGaugeValues(m.certificateSubjectFieldCount, values)
+}
+
+// SetCertificateGatedFeatureBlockedCount sets the number of Certificates
+// which set a field that the controller ignores because its feature gate is
+// disabled, keyed by the name of the feature gate. Feature gates which are
+// missing from counts are no longer reported.
+func (m *Metrics) SetCertificateGatedFeatureBlockedCount(counts map[string]int) {
+	m.setGaugeValues(m.certificateGatedFeatureBlockedCount, gaugeValuesFromCounts(counts))
+}
+
+// updateDistinctIssuerRefCount sets the number of distinct issuers referenced
+// by the Certificates. An issuerRef with an empty kind or group refers to the
+// same issuer as one using the default kind and group, and ClusterIssuers
+// referenced from different namespaces are the same issuer.
+func (m *Metrics) updateDistinctIssuerRefCount(crts []*cmapi.Certificate) {
+	type issuerRef struct {
+		name, kind, group, namespace string
+	}
+
+	refs := make(map[issuerRef]struct{})
+	for _, crt := range crts {
+		ref := issuerRef{
+			name:      crt.Spec.IssuerRef.Name,
+			kind:      crt.Spec.IssuerRef.Kind,
+			group:     crt.Spec.IssuerRef.Group,
+			namespace: crt.Namespace,
+		}
+		if ref.kind == "" {
+			ref.kind = cmapi.IssuerKind
+		}
+		if ref.group == "" {
+			ref.group = certmanager.GroupName
+		}
+		if ref.kind == cmapi.ClusterIssuerKind && ref.group == certmanager.GroupName {
+			ref.namespace = ""
+		}
+		refs[ref] = struct{}{}
+	}
+
+	m.distinctIssuerRefCount.Set(float64(len(refs)))
+}
+
+// updateCertificateInvalidDurationConfigCount counts the Certificates whose
+// renewBefore is greater than or equal to their duration. Certificates
+// without a duration use the default duration of 90 days.
+func (m *Metrics) updateCertificateInvalidDurationConfigCount(crts []*cmapi.Certificate) {
+	values := newGaugeValues()
+
+	for _, crt := range crts {
+		if crt.Spec.RenewBefore == nil {
+			continue
+		}
+		if crt.Spec.RenewBefore.Duration >= apiutil.DefaultCertDuration(crt.Spec.Duration) {
+			values.inc(crt.Namespace)
+		}
+	}
+
+	m.setGaugeValues(m.certificateInvalidDurationConfigCount, values)
+}
@@ -18,13 +18,19 @@ package metrics
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
 
 	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/clock"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -70,15 +76,15 @@ func TestCertificateMetrics(t *testing.T) {
 				}),
 			),
 			expectedExpiry: `
-	certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 2.208988804e+09
+	certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 2.208988804e+09
 `,
 			expectedReady: `
-        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
-        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 1
-        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
+        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 0
+        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 1
+        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 0
 `,
 			expectedRenewalTime: `
-		certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
+		certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 0
 `,
 		},
 
@@ -92,15 +98,15 @@ func TestCertificateMetrics(t *testing.T) {
 				}),
 			),
 			expectedExpiry: `
-	certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
+	certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 0
 `,
 			expectedReady: `
-        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
-        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
-        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 1
+        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 0
+        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 0
+        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 1
 `,
 			expectedRenewalTime: `
-		certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
+		certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 0
 `,
 		},
 
@@ -121,15 +127,15 @@ func TestCertificateMetrics(t *testing.T) {
 				}),
 			),
 			expectedExpiry: `
-	certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 100
+	certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 100
 `,
 			expectedReady: `
-        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 1
-        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
-        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
+        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 1
+        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 0
+        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 0
 `,
 			expectedRenewalTime: `
-		certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
+		certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 0
 `,
 		},
 		"certificate with expiry and status Unknown should give an expiry and Unknown status": {
@@ -149,15 +155,15 @@ func TestCertificateMetrics(t *testing.T) {
 				}),
 			),
 			expectedExpiry: `
-	certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 99999
+	certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 99999
 `,
 			expectedReady: `
-        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
-        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
-        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 1
+        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 0
+        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 0
+        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 1
 `,
 			expectedRenewalTime: `
-		certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
+		certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 0
 `,
 		},
 		"certificate with expiry and ready status and renew before": {
@@ -180,15 +186,15 @@ func TestCertificateMetrics(t *testing.T) {
 				}),
 			),
 			expectedExpiry: `
-	certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 2.208988804e+09
+	certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 2.208988804e+09
 `,
 			expectedReady: `
-        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
-        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 1
-        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
+        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 0
+        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 1
+        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 0
 `,
 			expectedRenewalTime: `
-		certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 2.208988804e+09
+		certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns"} 2.208988804e+09
 `,
 		},
 	}
@@ -286,15 +292,15 @@ func TestCertificateCache(t *testing.T) {
 	// Check all three metrics exist
 	if err := testutil.CollectAndCompare(m.certificateReadyStatus,
 		strings.NewReader(readyMetadata+`
-        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt1",namespace="default-unit-test-ns"} 0
-        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt2",namespace="default-unit-test-ns"} 0
-        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt3",namespace="default-unit-test-ns"} 1
-        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt1",namespace="default-unit-test-ns"} 0
-        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt2",namespace="default-unit-test-ns"} 1
-        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt3",namespace="default-unit-test-ns"} 0
-        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt1",namespace="default-unit-test-ns"} 1
-        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt2",namespace="default-unit-test-ns"} 0
-        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt3",namespace="default-unit-test-ns"} 0
+        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt1",namespace="default-unit-test-ns"} 0
+        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt2",namespace="default-unit-test-ns"} 0
+        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt3",namespace="default-unit-test-ns"} 1
+        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt1",namespace="default-unit-test-ns"} 0
+        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt2",namespace="default-unit-test-ns"} 1
+        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt3",namespace="default-unit-test-ns"} 0
+        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt1",namespace="default-unit-test-ns"} 1
+        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt2",namespace="default-unit-test-ns"} 0
+        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt3",namespace="default-unit-test-ns"} 0
 `),
 		"certmanager_certificate_ready_status",
 	); err != nil {
@@ -302,9 +308,9 @@ func TestCertificateCache(t *testing.T) {
 	}
 	if err := testutil.CollectAndCompare(m.certificateExpiryTimeSeconds,
 		strings.NewReader(expiryMetadata+`
-        certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt1",namespace="default-unit-test-ns"} 100
-        certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt2",namespace="default-unit-test-ns"} 200
-        certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt3",namespace="default-unit-test-ns"} 300
+        certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt1",namespace="default-unit-test-ns"} 100
+        certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt2",namespace="default-unit-test-ns"} 200
+        certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt3",namespace="default-unit-test-ns"} 300
 `),
 		"certmanager_certificate_expiration_timestamp_seconds",
 	); err != nil {
@@ -313,9 +319,9 @@ func TestCertificateCache(t *testing.T) {
 
 	if err := testutil.CollectAndCompare(m.certificateRenewalTimeSeconds,
 		strings.NewReader(renewalTimeMetadata+`
-        certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt1",namespace="default-unit-test-ns"} 100
-        certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt2",namespace="default-unit-test-ns"} 200
-        certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt3",namespace="default-unit-test-ns"} 300
+        certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt1",namespace="default-unit-test-ns"} 100
+        certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt2",namespace="default-unit-test-ns"} 200
+        certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt3",namespace="default-unit-test-ns"} 300
 `),
 		"certmanager_certificate_renewal_timestamp_seconds",
 	); err != nil {
@@ -326,12 +332,12 @@ func TestCertificateCache(t *testing.T) {
 	m.RemoveCertificate("default-unit-test-ns/crt2")
 	if err := testutil.CollectAndCompare(m.certificateReadyStatus,
 		strings.NewReader(readyMetadata+`
-        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt1",namespace="default-unit-test-ns"} 0
-        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt3",namespace="default-unit-test-ns"} 1
-        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt1",namespace="default-unit-test-ns"} 0
-        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt3",namespace="default-unit-test-ns"} 0
-        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt1",namespace="default-unit-test-ns"} 1
-        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt3",namespace="default-unit-test-ns"} 0
+        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt1",namespace="default-unit-test-ns"} 0
+        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt3",namespace="default-unit-test-ns"} 1
+        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt1",namespace="default-unit-test-ns"} 0
+        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt3",namespace="default-unit-test-ns"} 0
+        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt1",namespace="default-unit-test-ns"} 1
+        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt3",namespace="default-unit-test-ns"} 0
 `),
 		"certmanager_certificate_ready_status",
 	); err != nil {
@@ -339,8 +345,8 @@ func TestCertificateCache(t *testing.T) {
 	}
 	if err := testutil.CollectAndCompare(m.certificateExpiryTimeSeconds,
 		strings.NewReader(expiryMetadata+`
-        certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt1",namespace="default-unit-test-ns"} 100
-        certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt3",namespace="default-unit-test-ns"} 300
+        certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt1",namespace="default-unit-test-ns"} 100
+        certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="default-unit-test-ns",name="crt3",namespace="default-unit-test-ns"} 300
 `),
 		"certmanager_certificate_expiration_timestamp_seconds",
 	); err != nil {
@@ -364,3 +370,190 @@ func TestCertificateCache(t *testing.T) {
 		t.Errorf("unexpected collecting result:\n%s", err)
 	}
 }
+
+func TestRemoveCertificateKeepsSiblings(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	certificate := func(name, namespace string) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace(namespace),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer"}),
+			gen.SetCertificateNotAfter(metav1.Time{Time: time.Unix(100, 0)}),
+			gen.SetCertificateRenewalTime(metav1.Time{Time: time.Unix(50, 0)}),
+			gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type:   cmapi.CertificateConditionReady,
+				Status: cmmeta.ConditionTrue,
+			}),
+		)
+	}
+	for _, crt := range []*cmapi.Certificate{
+		certificate("crt", "ns1"),
+		// A Certificate of the same name in another namespace.
+		certificate("crt", "ns2"),
+		// Another Certificate in the same namespace.
+		certificate("other", "ns1"),
+	} {
+		m.UpdateCertificate(context.TODO(), crt)
+	}
+
+	m.RemoveCertificate("ns1/crt")
+
+	for name, c := range map[string]prometheus.Collector{
+		"certmanager_certificate_expiration_timestamp_seconds": m.certificateExpiryTimeSeconds,
+		"certmanager_certificate_renewal_timestamp_seconds":    m.certificateRenewalTimeSeconds,
+		"certmanager_certificate_ready_status":                 m.certificateReadyStatus,
+	} {
+		remaining := make(map[string]bool)
+		ch := make(chan prometheus.Metric, 16)
+		c.Collect(ch)
+		close(ch)
+		for metric := range ch {
+			var pb dto.Metric
+			if err := metric.Write(&pb); err != nil {
+				t.Fatal(err)
+			}
+			labels := make(map[string]string)
+			for _, l := range pb.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			remaining[labels["namespace"]+"/"+labels["name"]] = true
+		}
+
+		assert.Equal(t, map[string]bool{"ns1/other": true, "ns2/crt": true}, remaining, name)
+	}
+}
+
+func TestCertificateReadyStatusReason(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{}, WithCertificateReadyStatusReason(true))
+
+	crt := gen.Certificate("test-certificate",
+		gen.SetCertificateNamespace("test-ns"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{
+			Name:  "test-issuer",
+			Kind:  "test-issuer-kind",
+			Group: "test-issuer-group",
+		}),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionReady,
+			Status: cmmeta.ConditionFalse,
+			Reason: "InProgress",
+		}),
+	)
+	m.UpdateCertificate(context.TODO(), crt)
+
+	// Once the reason changes, series with the old reason should be removed.
+	crt = gen.CertificateFrom(crt,
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionReady,
+			Status: cmmeta.ConditionTrue,
+			Reason: "Ready",
+		}),
+	)
+	m.UpdateCertificate(context.TODO(), crt)
+
+	if err := testutil.CollectAndCompare(m.certificateReadyStatus,
+		strings.NewReader(readyMetadata+`
+        certmanager_certificate_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns",reason="Ready"} 0
+        certmanager_certificate_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns",reason="Ready"} 1
+        certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-certificate",namespace="test-ns",reason="Ready"} 0
+`),
+		"certmanager_certificate_ready_status",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestCertificateMetricsIssuerNamespace(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	for _, kind := range []string{"Issuer", "ClusterIssuer"} {
+		crt := gen.Certificate("test-"+strings.ToLower(kind),
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{
+				Name:  "test-issuer",
+				Kind:  kind,
+				Group: "cert-manager.io",
+			}),
+		)
+		m.UpdateCertificate(context.TODO(), crt)
+	}
+
+	if err := testutil.CollectAndCompare(m.certificateExpiryTimeSeconds,
+		strings.NewReader(expiryMetadata+`
+        certmanager_certificate_expiration_timestamp_seconds{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="test-issuer",issuer_namespace="",name="test-clusterissuer",namespace="test-ns"} 0
+        certmanager_certificate_expiration_timestamp_seconds{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="test-issuer",issuer_namespace="test-ns",name="test-issuer",namespace="test-ns"} 0
+`),
+		"certmanager_certificate_expiration_timestamp_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const reconcileErrorMetadata = `
+	# HELP certmanager_certificate_reconcile_error_count The number of errors encountered while reconciling Certificates, by the issuer they reference and the reason for the error.
+	# TYPE certmanager_certificate_reconcile_error_count counter
+`
+
+func TestIncrementCertificateReconcileError(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crt := gen.Certificate("test-certificate",
+		gen.SetCertificateNamespace("test-ns"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{
+			Name:  "test-issuer",
+			Kind:  "Issuer",
+			Group: "cert-manager.io",
+		}),
+	)
+	m.IncrementCertificateReconcileError(crt, apierrors.NewConflict(schema.GroupResource{Resource: "secrets"}, "test-secret", errors.New("conflict")))
+	m.IncrementCertificateReconcileError(crt, apierrors.NewConflict(schema.GroupResource{Resource: "secrets"}, "test-secret", errors.New("conflict")))
+	m.IncrementCertificateReconcileError(crt, errors.New("some error"))
+
+	if err := testutil.CollectAndCompare(m.certificateReconcileErrorCount,
+		strings.NewReader(reconcileErrorMetadata+`
+	certmanager_certificate_reconcile_error_count{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="test-issuer",reason="Conflict"} 2
+	certmanager_certificate_reconcile_error_count{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="test-issuer",reason="Unknown"} 1
+`),
+		"certmanager_certificate_reconcile_error_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const issuanceDurationMetadata = `
+	# HELP certmanager_certificate_issuance_duration_seconds The time between a Certificate being created and first becoming Ready, by the issuer referenced.
+	# TYPE certmanager_certificate_issuance_duration_seconds histogram
+`
+
+func TestObserveCertificateIssuanceDuration(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	for _, d := range []time.Duration{3 * time.Second, 45 * time.Second, 10 * time.Minute} {
+		m.ObserveCertificateIssuanceDuration("ca", "Issuer", "cert-manager.io", d)
+	}
+
+	if err := testutil.CollectAndCompare(m.certificateIssuanceDurationSeconds,
+		strings.NewReader(issuanceDurationMetadata+`
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca",le="1"} 0
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca",le="2"} 0
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca",le="4"} 1
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca",le="8"} 1
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca",le="16"} 1
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca",le="32"} 1
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca",le="64"} 2
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca",le="128"} 2
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca",le="256"} 2
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca",le="512"} 2
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca",le="1024"} 3
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca",le="2048"} 3
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca",le="4096"} 3
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca",le="8192"} 3
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca",le="+Inf"} 3
+	certmanager_certificate_issuance_duration_seconds_sum{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca"} 648
+	certmanager_certificate_issuance_duration_seconds_count{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca"} 3
+`),
+		"certmanager_certificate_issuance_duration_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// gaugeValues are the values of the series of a GaugeVec which is recomputed
+// on each resync, keyed by their label values.
+type gaugeValues struct {
+	labelValues map[string][]string
+	values      map[string]float64
+}
+
+func newGaugeValues() *gaugeValues {
+	return &gaugeValues{
+		labelValues: make(map[string][]string),
+		values:      make(map[string]float64),
+	}
+}
+
+// add increases the value of the series with the given label values, adding
+// the series if it does not exist.
+func (v *gaugeValues) add(delta float64, labelValues ...string) {
+	key := strings.Join(labelValues, "\xff")
+	v.labelValues[key] = labelValues
+	v.values[key] += delta
+}
+
+// inc increases the value of the series with the given label values by one.
+func (v *gaugeValues) inc(labelValues ...string) {
+	v.add(1, labelValues...)
+}
+
+// set sets the value of the series with the given label values.
+func (v *gaugeValues) set(value float64, labelValues ...string) {
+	key := strings.Join(labelValues, "\xff")
+	v.labelValues[key] = labelValues
+	v.values[key] = value
+}
+
+// observe adds the given value to a cumulative distribution over the given
+// bucket upper bounds, in the same form as the buckets of a histogram. The
+// upper bound of each bucket is appended to the given label values as the
+// `le` label, and the series for every bucket is added, including those the
+// value does not fall into. Unlike a histogram, the distribution can be
+// recomputed from scratch on each resync.
+func (v *gaugeValues) observe(buckets []float64, value float64, labelValues ...string) {
+	upperBounds := make([]float64, 0, len(buckets)+1)
+	upperBounds = append(upperBounds, buckets...)
+	upperBounds = append(upperBounds, math.Inf(1))
+
+	for _, upperBound := range upperBounds {
+		var delta float64
+		if value <= upperBound {
+			delta = 1
+		}
+		bucketLabelValues := make([]string, 0, len(labelValues)+1)
+		bucketLabelValues = append(bucketLabelValues, labelValues...)
+		v.add(delta, append(bucketLabelValues, formatUpperBound(upperBound))...)
+	}
+}
+
+// formatUpperBound formats the upper bound of a bucket as the value of an
+// `le` label, matching the format used for histograms.
+func formatUpperBound(upperBound float64) string {
+	if math.IsInf(upperBound, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(upperBound, 'g', -1, 64)
+}
+
+// gaugeValuesFromCounts returns the given counts as the values of a GaugeVec
+// with a single label, whose values are the keys of counts.
+func gaugeValuesFromCounts(counts map[string]int) *gaugeValues {
+	values := newGaugeValues()
+	for labelValue, count := range counts {
+		values.set(float64(count), labelValue)
+	}
+	return values
+}
+
+// setGaugeValues replaces the series of the given GaugeVec with the given
+// values. Resetting the GaugeVec and then setting each series would expose
+// it empty or partially recomputed to scrapes made in between. Instead, each
+// series is set to its new value and only the series which no longer have a
+// value are deleted.
+func (m *Metrics) setGaugeValues(vec *prometheus.GaugeVec, values *gaugeValues) {
+	m.resyncSeriesLock.Lock()
+	defer m.resyncSeriesLock.Unlock()
+
+	for key, labelValues := range m.resyncSeries[vec] {
+		if _, ok := values.values[key]; !ok {
+			vec.DeleteLabelValues(labelValues...)
+		}
+	}
+	for key, value := range values.values {
+		vec.WithLabelValues(values.labelValues[key]...).Set(value)
+	}
+	m.resyncSeries[vec] = values.labelValues
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/clock"
+)
+
+func TestSetGaugeValues(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+	vec := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test_gauge", Help: "A test gauge."}, []string{"namespace", "kind"})
+
+	first := newGaugeValues()
+	first.inc("ns1", "Issuer")
+	first.inc("ns1", "Issuer")
+	first.inc("ns2", "Issuer")
+	first.set(5, "ns3", "ClusterIssuer")
+	m.setGaugeValues(vec, first)
+
+	// Series which are not in the new values are deleted, and the others are
+	// set to their new values.
+	second := newGaugeValues()
+	second.inc("ns1", "Issuer")
+	second.add(3, "ns3", "ClusterIssuer")
+	m.setGaugeValues(vec, second)
+
+	if err := testutil.CollectAndCompare(vec, strings.NewReader(`
+	# HELP test_gauge A test gauge.
+	# TYPE test_gauge gauge
+	test_gauge{kind="ClusterIssuer",namespace="ns3"} 3
+	test_gauge{kind="Issuer",namespace="ns1"} 1
+`), "test_gauge"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
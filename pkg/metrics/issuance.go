@@ -0,0 +1,41 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+// IssuanceEvent describes an update to one of the issuance metrics, which
+// are certificate_issued_count and vault_issuance_count.
+type IssuanceEvent struct {
+	// Metric is the fully-qualified name of the updated metric, e.g.
+	// `certmanager_certificate_issued_count`.
+	Metric string
+
+	// Labels are the label values of the updated series, keyed by label name.
+	Labels map[string]string
+
+	// Value is the amount the series was increased by.
+	Value float64
+}
+
+// notifyIssuance passes the given event to the issuance callback, if one is
+// set. The callback is run in its own goroutine so that a slow sink cannot
+// block the caller recording the metric.
+func (m *Metrics) notifyIssuance(event IssuanceEvent) {
+	if m.opts.onIssuance == nil {
+		return
+	}
+	go m.opts.onIssuance(event)
+}
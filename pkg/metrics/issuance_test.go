@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/clock"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestOnIssuance(t *testing.T) {
+	events := make(chan IssuanceEvent)
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{}, WithOnIssuance(func(event IssuanceEvent) {
+		events <- event
+	}))
+
+	receive := func() IssuanceEvent {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for issuance event")
+			return IssuanceEvent{}
+		}
+	}
+
+	// The callback blocks until the event is received, which must not block
+	// recording the metric.
+	m.IncrementCertificateIssued(cmmeta.ObjectReference{Name: "test", Kind: "Issuer", Group: "cert-manager.io"}, CSRSourceController)
+	assert.Equal(t, IssuanceEvent{
+		Metric: "certmanager_certificate_issued_count",
+		Labels: map[string]string{"issuer_kind": "Issuer", "issuer_group": "cert-manager.io", "csr_source": CSRSourceController},
+		Value:  1,
+	}, receive())
+
+	m.IncrementVaultIssuance("example", "pki/sign/example", VaultIssuanceResultSuccess)
+	assert.Equal(t, IssuanceEvent{
+		Metric: "certmanager_vault_issuance_count",
+		Labels: map[string]string{"role": "example", "path": "pki/sign/example", "result": VaultIssuanceResultSuccess},
+		Value:  1,
+	}, receive())
+}
+
+func TestOnIssuanceUnset(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	// Recording issuance metrics without a callback should not panic.
+	m.IncrementCertificateIssued(cmmeta.ObjectReference{Kind: "Issuer", Group: "cert-manager.io"}, CSRSourceController)
+	m.IncrementVaultIssuance("example", "pki/sign/example", VaultIssuanceResultSuccess)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// IncrementIssuerQuotaExceeded increases the count of signing attempts made
+// using the referenced issuer which failed because the CA reported that a
+// quota was exceeded.
+func (m *Metrics) IncrementIssuerQuotaExceeded(ref cmmeta.ObjectReference) {
+	m.issuerQuotaExceededCount.WithLabelValues(ref.Name, ref.Kind, ref.Group).Inc()
+}
+
+// updateCertificateIssuerSelectorMismatchCount counts the Certificates which
+// reference an Issuer that does not exist in their namespace, but for which
+// an Issuer of the same name exists in another namespace, or a ClusterIssuer
+// of the same name exists. Issuers are namespace scoped, so these
+// Certificates will never be issued.
+func (m *Metrics) updateCertificateIssuerSelectorMismatchCount(crts []*cmapi.Certificate, issuers []*cmapi.Issuer, clusterIssuers []*cmapi.ClusterIssuer) {
+	values := newGaugeValues()
+
+	issuersByName := make(map[types.NamespacedName]struct{}, len(issuers))
+	otherScopeNames := make(map[string]struct{}, len(issuers)+len(clusterIssuers))
+	for _, issuer := range issuers {
+		issuersByName[types.NamespacedName{Namespace: issuer.Namespace, Name: issuer.Name}] = struct{}{}
+		otherScopeNames[issuer.Name] = struct{}{}
+	}
+	for _, clusterIssuer := range clusterIssuers {
+		otherScopeNames[clusterIssuer.Name] = struct{}{}
+	}
+
+	for _, crt := range crts {
+		ref := crt.Spec.IssuerRef
+		if ref.Group != "" && ref.Group != certmanager.GroupName {
+			continue
+		}
+		if ref.Kind != "" && ref.Kind != cmapi.IssuerKind {
+			continue
+		}
+		if _, ok := issuersByName[types.NamespacedName{Namespace: crt.Namespace, Name: ref.Name}]; ok {
+			continue
+		}
+		if _, ok := otherScopeNames[ref.Name]; !ok {
+			continue
+		}
+
+		values.inc(crt.Namespace)
+	}
+
+	m.setGaugeValues(m.certificateIssuerSelectorMismatchCount, values)
+}
+
+// updateCertificateCrossNamespaceSecretRefCount counts the Certificates in
+// namespaces other than the cluster resource namespace which reference a
+// ClusterIssuer that uses Secrets, e.g. a CA key pair or ACME account key.
+// Those Secrets are read from the cluster resource namespace to issue the
+// Certificate. SelfSigned ClusterIssuers do not reference any Secrets.
+func (m *Metrics) updateCertificateCrossNamespaceSecretRefCount(crts []*cmapi.Certificate, clusterIssuers []*cmapi.ClusterIssuer, clusterResourceNamespace string) {
+	values := newGaugeValues()
+
+	usesSecrets := make(map[string]bool, len(clusterIssuers))
+	for _, clusterIssuer := range clusterIssuers {
+		usesSecrets[clusterIssuer.Name] = clusterIssuer.Spec.SelfSigned == nil
+	}
+
+	for _, crt := range crts {
+		ref := crt.Spec.IssuerRef
+		if ref.Group != "" && ref.Group != certmanager.GroupName {
+			continue
+		}
+		if ref.Kind != cmapi.ClusterIssuerKind {
+			continue
+		}
+		if !usesSecrets[ref.Name] || crt.Namespace == clusterResourceNamespace {
+			continue
+		}
+
+		values.inc(crt.Namespace, clusterResourceNamespace)
+	}
+
+	m.setGaugeValues(m.certificateCrossNamespaceSecretRefCount, values)
+}
+
+// updateCertificateBlockedByNotReadyIssuerCount counts the Certificates which
+// reference an Issuer or ClusterIssuer that exists but is not Ready, so
+// cannot be issued until it recovers. The readiness of external issuers is
+// not known, so Certificates referencing them are not counted.
+func (m *Metrics) updateCertificateBlockedByNotReadyIssuerCount(crts []*cmapi.Certificate, issuers []*cmapi.Issuer, clusterIssuers []*cmapi.ClusterIssuer) {
+	values := newGaugeValues()
+
+	notReadyIssuers := make(map[types.NamespacedName]struct{})
+	for _, issuer := range issuers {
+		if !issuerIsReady(issuer) {
+			notReadyIssuers[types.NamespacedName{Namespace: issuer.Namespace, Name: issuer.Name}] = struct{}{}
+		}
+	}
+	notReadyClusterIssuers := make(map[string]struct{})
+	for _, clusterIssuer := range clusterIssuers {
+		if !issuerIsReady(clusterIssuer) {
+			notReadyClusterIssuers[clusterIssuer.Name] = struct{}{}
+		}
+	}
+
+	for _, crt := range crts {
+		ref := crt.Spec.IssuerRef
+		if ref.Group != "" && ref.Group != certmanager.GroupName {
+			continue
+		}
+
+		var notReady bool
+		switch ref.Kind {
+		case "", cmapi.IssuerKind:
+			_, notReady = notReadyIssuers[types.NamespacedName{Namespace: crt.Namespace, Name: ref.Name}]
+		case cmapi.ClusterIssuerKind:
+			_, notReady = notReadyClusterIssuers[ref.Name]
+		}
+		if notReady {
+			values.inc(ref.Name, ref.Kind, ref.Group)
+		}
+	}
+
+	m.setGaugeValues(m.certificateBlockedByNotReadyIssuerCount, values)
+}
+
+// issuerIsReady returns true if the issuer has a Ready condition with status
+// True.
+func issuerIsReady(issuer cmapi.GenericIssuer) bool {
+	return apiutil.IssuerHasCondition(issuer, cmapi.IssuerCondition{
+		Type:   cmapi.IssuerConditionReady,
+		Status: cmmeta.ConditionTrue,
+	})
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+const issuerSelectorMismatchMetadata = `
+	# HELP certmanager_certificate_issuer_selector_mismatch_count The number of Certificates referencing an Issuer which only exists outside of their namespace, or as a ClusterIssuer.
+	# TYPE certmanager_certificate_issuer_selector_mismatch_count gauge
+`
+
+func TestResyncCertificateIssuerSelectorMismatchCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crtWithIssuer := func(name, namespace, issuerName, kind string) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace(namespace),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: issuerName, Kind: kind}),
+		)
+	}
+
+	m.Resync(ResyncState{
+		Certificates: []*cmapi.Certificate{
+			crtWithIssuer("in-namespace", "ns1", "issuer1", "Issuer"),
+			// The Issuer only exists in ns1.
+			crtWithIssuer("other-namespace", "ns2", "issuer1", "Issuer"),
+			// A ClusterIssuer exists with this name, but the kind is Issuer.
+			crtWithIssuer("cluster-issuer", "ns2", "cluster1", ""),
+			crtWithIssuer("correct-kind", "ns2", "cluster1", "ClusterIssuer"),
+			// Issuers which do not exist anywhere are not a scoping problem.
+			crtWithIssuer("missing", "ns2", "missing", "Issuer"),
+		},
+		Issuers: []*cmapi.Issuer{
+			gen.Issuer("issuer1", gen.SetIssuerNamespace("ns1")),
+		},
+		ClusterIssuers: []*cmapi.ClusterIssuer{
+			gen.ClusterIssuer("cluster1"),
+		},
+	})
+	if err := testutil.CollectAndCompare(m.certificateIssuerSelectorMismatchCount,
+		strings.NewReader(issuerSelectorMismatchMetadata+`
+	certmanager_certificate_issuer_selector_mismatch_count{namespace="ns2"} 2
+`),
+		"certmanager_certificate_issuer_selector_mismatch_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const crossNamespaceSecretRefMetadata = `
+	# HELP certmanager_certificate_cross_namespace_secret_ref_count The number of Certificates issued using Secrets in another namespace, such as those of a ClusterIssuer, by the Certificate's namespace and the Secrets' namespace.
+	# TYPE certmanager_certificate_cross_namespace_secret_ref_count gauge
+`
+
+func TestResyncCertificateCrossNamespaceSecretRefCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crtWithIssuer := func(name, namespace, issuerName, kind string) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace(namespace),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: issuerName, Kind: kind}),
+		)
+	}
+
+	m.Resync(ResyncState{
+		Certificates: []*cmapi.Certificate{
+			crtWithIssuer("ca1", "ns1", "ca", "ClusterIssuer"),
+			crtWithIssuer("ca2", "ns1", "ca", "ClusterIssuer"),
+			crtWithIssuer("ca3", "ns2", "ca", "ClusterIssuer"),
+			// Certificates in the cluster resource namespace do not cross
+			// namespaces.
+			crtWithIssuer("ca4", "cert-manager", "ca", "ClusterIssuer"),
+			// SelfSigned ClusterIssuers do not reference any Secrets.
+			crtWithIssuer("selfsigned", "ns1", "selfsigned", "ClusterIssuer"),
+			// Issuers reference Secrets in their own namespace.
+			crtWithIssuer("issuer", "ns1", "ca", "Issuer"),
+		},
+		ClusterIssuers: []*cmapi.ClusterIssuer{
+			gen.ClusterIssuer("ca", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca-key-pair"})),
+			gen.ClusterIssuer("selfsigned", gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{})),
+		},
+		ClusterResourceNamespace: "cert-manager",
+	})
+	if err := testutil.CollectAndCompare(m.certificateCrossNamespaceSecretRefCount,
+		strings.NewReader(crossNamespaceSecretRefMetadata+`
+	certmanager_certificate_cross_namespace_secret_ref_count{source_namespace="ns1",target_namespace="cert-manager"} 2
+	certmanager_certificate_cross_namespace_secret_ref_count{source_namespace="ns2",target_namespace="cert-manager"} 1
+`),
+		"certmanager_certificate_cross_namespace_secret_ref_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Counts from the previous resync should not be carried over.
+	m.Resync(ResyncState{ClusterResourceNamespace: "cert-manager"})
+	if err := testutil.CollectAndCompare(m.certificateCrossNamespaceSecretRefCount,
+		strings.NewReader(""),
+		"certmanager_certificate_cross_namespace_secret_ref_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const blockedByNotReadyIssuerMetadata = `
+	# HELP certmanager_certificate_blocked_by_notready_issuer_count The number of Certificates referencing an Issuer or ClusterIssuer which is not Ready.
+	# TYPE certmanager_certificate_blocked_by_notready_issuer_count gauge
+`
+
+func TestResyncCertificateBlockedByNotReadyIssuerCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crtWithIssuer := func(name, namespace, issuerName, kind, group string) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace(namespace),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: issuerName, Kind: kind, Group: group}),
+		)
+	}
+	ready := gen.AddIssuerCondition(cmapi.IssuerCondition{
+		Type:   cmapi.IssuerConditionReady,
+		Status: cmmeta.ConditionTrue,
+	})
+	notReady := gen.AddIssuerCondition(cmapi.IssuerCondition{
+		Type:   cmapi.IssuerConditionReady,
+		Status: cmmeta.ConditionFalse,
+	})
+
+	m.Resync(ResyncState{
+		Certificates: []*cmapi.Certificate{
+			crtWithIssuer("crt1", "ns1", "broken", "Issuer", "cert-manager.io"),
+			crtWithIssuer("crt2", "ns1", "broken", "Issuer", "cert-manager.io"),
+			// The Issuer named broken in ns2 is Ready.
+			crtWithIssuer("crt3", "ns2", "broken", "Issuer", "cert-manager.io"),
+			crtWithIssuer("crt4", "ns2", "cluster-broken", "ClusterIssuer", "cert-manager.io"),
+			crtWithIssuer("crt5", "ns2", "cluster-ready", "ClusterIssuer", "cert-manager.io"),
+			// Issuers which do not exist are not counted.
+			crtWithIssuer("crt6", "ns2", "missing", "Issuer", "cert-manager.io"),
+			// The readiness of external issuers is not known.
+			crtWithIssuer("crt7", "ns1", "broken", "Issuer", "example.com"),
+		},
+		Issuers: []*cmapi.Issuer{
+			gen.Issuer("broken", gen.SetIssuerNamespace("ns1"), notReady),
+			gen.Issuer("broken", gen.SetIssuerNamespace("ns2"), ready),
+		},
+		ClusterIssuers: []*cmapi.ClusterIssuer{
+			// ClusterIssuers without a Ready condition are not Ready.
+			gen.ClusterIssuer("cluster-broken"),
+			gen.ClusterIssuer("cluster-ready", ready),
+		},
+	})
+	if err := testutil.CollectAndCompare(m.certificateBlockedByNotReadyIssuerCount,
+		strings.NewReader(blockedByNotReadyIssuerMetadata+`
+	certmanager_certificate_blocked_by_notready_issuer_count{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="cluster-broken"} 1
+	certmanager_certificate_blocked_by_notready_issuer_count{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="broken"} 2
+`),
+		"certmanager_certificate_blocked_by_notready_issuer_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Counts from the previous resync should not be carried over.
+	m.Resync(ResyncState{})
+	if err := testutil.CollectAndCompare(m.certificateBlockedByNotReadyIssuerCount,
+		strings.NewReader(""),
+		"certmanager_certificate_blocked_by_notready_issuer_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// updateCertificateKeystorePasswordMissingCount recomputes the number of
+// Certificates, by namespace, which request a JKS or PKCS#12 keystore but
+// whose password cannot be read because the referenced Secret or key does not
+// exist. A Certificate is counted once even if both keystores are affected.
+func (m *Metrics) updateCertificateKeystorePasswordMissingCount(crts []*cmapi.Certificate, passwordSecrets []*corev1.Secret) {
+	values := newGaugeValues()
+
+	secrets := make(map[types.NamespacedName]*corev1.Secret, len(passwordSecrets))
+	for _, secret := range passwordSecrets {
+		secrets[types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}] = secret
+	}
+
+	hasPassword := func(namespace string, ref cmmeta.SecretKeySelector) bool {
+		secret, ok := secrets[types.NamespacedName{Namespace: namespace, Name: ref.Name}]
+		if !ok {
+			return false
+		}
+		_, ok = secret.Data[ref.Key]
+		return ok
+	}
+
+	for _, crt := range crts {
+		missing := false
+		for _, ref := range keystorePasswordSecretRefs(crt) {
+			if !hasPassword(crt.Namespace, ref) {
+				missing = true
+			}
+		}
+		if missing {
+			values.inc(crt.Namespace)
+		}
+	}
+
+	m.setGaugeValues(m.certificateKeystorePasswordMissingCount, values)
+}
+
+// keystorePasswordSecretRefs returns the password Secret references of the
+// keystores which the Certificate requests to be created.
+func keystorePasswordSecretRefs(crt *cmapi.Certificate) []cmmeta.SecretKeySelector {
+	keystores := crt.Spec.Keystores
+	if keystores == nil {
+		return nil
+	}
+
+	var refs []cmmeta.SecretKeySelector
+	if keystores.JKS != nil && keystores.JKS.Create {
+		refs = append(refs, keystores.JKS.PasswordSecretRef)
+	}
+	if keystores.PKCS12 != nil && keystores.PKCS12.Create {
+		refs = append(refs, keystores.PKCS12.PasswordSecretRef)
+	}
+	return refs
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+const keystorePasswordMissingMetadata = `
+	# HELP certmanager_certificate_keystore_password_missing_count The number of Certificates which request a JKS or PKCS#12 keystore but whose password Secret, or the key within it, does not exist.
+	# TYPE certmanager_certificate_keystore_password_missing_count gauge
+`
+
+func TestResyncCertificateKeystorePasswordMissingCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crtWithKeystores := func(name, namespace string, keystores *cmapi.CertificateKeystores) *cmapi.Certificate {
+		crt := gen.Certificate(name, gen.SetCertificateNamespace(namespace))
+		crt.Spec.Keystores = keystores
+		return crt
+	}
+	passwordRef := func(name string) cmmeta.SecretKeySelector {
+		return cmmeta.SecretKeySelector{
+			LocalObjectReference: cmmeta.LocalObjectReference{Name: name},
+			Key:                  "password",
+		}
+	}
+
+	m.Resync(ResyncState{
+		Certificates: []*cmapi.Certificate{
+			crtWithKeystores("no-keystores", "ns1", nil),
+			crtWithKeystores("jks-present", "ns1", &cmapi.CertificateKeystores{
+				JKS: &cmapi.JKSKeystore{Create: true, PasswordSecretRef: passwordRef("password")},
+			}),
+			crtWithKeystores("jks-missing-secret", "ns1", &cmapi.CertificateKeystores{
+				JKS: &cmapi.JKSKeystore{Create: true, PasswordSecretRef: passwordRef("does-not-exist")},
+			}),
+			// Keystores which are not created do not need a password.
+			crtWithKeystores("jks-not-created", "ns1", &cmapi.CertificateKeystores{
+				JKS: &cmapi.JKSKeystore{Create: false, PasswordSecretRef: passwordRef("does-not-exist")},
+			}),
+			crtWithKeystores("pkcs12-missing-key", "ns1", &cmapi.CertificateKeystores{
+				PKCS12: &cmapi.PKCS12Keystore{Create: true, PasswordSecretRef: passwordRef("no-password-key")},
+			}),
+			// A Certificate with both keystores affected is counted once.
+			crtWithKeystores("both-missing", "ns1", &cmapi.CertificateKeystores{
+				JKS:    &cmapi.JKSKeystore{Create: true, PasswordSecretRef: passwordRef("does-not-exist")},
+				PKCS12: &cmapi.PKCS12Keystore{Create: true, PasswordSecretRef: passwordRef("does-not-exist")},
+			}),
+			// The password Secret must be in the Certificate's namespace.
+			crtWithKeystores("other-namespace", "ns2", &cmapi.CertificateKeystores{
+				PKCS12: &cmapi.PKCS12Keystore{Create: true, PasswordSecretRef: passwordRef("password")},
+			}),
+		},
+		KeystorePasswordSecrets: []*corev1.Secret{
+			testSecret("password", "ns1", map[string][]byte{"password": []byte("changeit")}),
+			testSecret("no-password-key", "ns1", map[string][]byte{"other": []byte("changeit")}),
+		},
+	})
+	if err := testutil.CollectAndCompare(m.certificateKeystorePasswordMissingCount,
+		strings.NewReader(keystorePasswordMissingMetadata+`
+	certmanager_certificate_keystore_password_missing_count{namespace="ns1"} 3
+	certmanager_certificate_keystore_password_missing_count{namespace="ns2"} 1
+`),
+		"certmanager_certificate_keystore_password_missing_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Counts are reset on each resync.
+	m.Resync(ResyncState{})
+	if n := testutil.CollectAndCount(m.certificateKeystorePasswordMissingCount); n != 0 {
+		t.Errorf("expected no series after resync with no Certificates, got %d", n)
+	}
+}
@@ -14,60 +14,429 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package metrics contains global structures related to metrics collection
-// cert-manager exposes the following metrics:
-// certificate_expiration_timestamp_seconds{name, namespace, issuer_name, issuer_kind, issuer_group}
-// certificate_renewal_timestamp_seconds{name, namespace, issuer_name, issuer_kind, issuer_group}
-// certificate_ready_status{name, namespace, condition, issuer_name, issuer_kind, issuer_group}
-// acme_client_request_count{"scheme", "host", "path", "method", "status"}
-// acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
-// venafi_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
-// controller_sync_call_count{"controller"}
+// Package metrics contains global structures related to metrics collection.
+// The metrics exposed by cert-manager are defined in New, each with a help
+// text describing it, and are served by the metrics server created by
+// NewServer. The webhook exposes only its own subset of them, see NewWebhook.
 package metrics
 
 import (
+	"fmt"
 	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	"k8s.io/utils/clock"
 
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 )
 
 const (
-	// Namespace is the namespace for cert-manager metric names
-	namespace                             = "certmanager"
+	// defaultNamespace is the default namespace for cert-manager metric names
+	defaultNamespace                      = "certmanager"
 	prometheusMetricsServerReadTimeout    = 8 * time.Second
 	prometheusMetricsServerWriteTimeout   = 8 * time.Second
 	prometheusMetricsServerMaxHeaderBytes = 1 << 20 // 1 MiB
+	prometheusMetricsServerIdleTimeout    = 120 * time.Second
 )
 
+// Option configures optional behaviour of the Metrics.
+type Option func(*options)
+
+type options struct {
+	// namespace is the prefix of all metric names.
+	namespace string
+
+	// idleTimeout is the maximum amount of time the metrics server will wait
+	// for the next request on a keep-alive connection.
+	idleTimeout time.Duration
+
+	// certificateReadyStatusReason adds a reason label to the
+	// certificate_ready_status metric.
+	certificateReadyStatusReason bool
+
+	// disableVaultIssuanceLabels leaves the role and path labels of the
+	// vault_issuance_count metric empty.
+	disableVaultIssuanceLabels bool
+
+	// zeroValuedSeries pre-populates counters with zero-valued series for
+	// known label values when the Metrics are created.
+	zeroValuedSeries bool
+
+	// zeroValuedSeriesControllers are the controller label values to
+	// pre-populate.
+	zeroValuedSeriesControllers []string
+
+	// summaryObjectives overrides the quantile objectives of summaries,
+	// keyed by their fully-qualified metric name.
+	summaryObjectives map[string]map[float64]float64
+
+	// histogramBuckets overrides the buckets of histograms, keyed by their
+	// fully-qualified metric name.
+	histogramBuckets map[string][]float64
+
+	// adminSubjectAccessReviews, if set, serves the admin endpoints on the
+	// metrics server and authorizes requests to them. They are only served if
+	// tokenReviewAuthentication is also set.
+	adminSubjectAccessReviews authorizationv1client.SubjectAccessReviewInterface
+
+	// utf8MetricNames requests UTF-8 metric names, where supported by the
+	// Prometheus client library.
+	utf8MetricNames bool
+
+	// webhookSlowRequestThreshold is the duration after which a webhook
+	// request is counted as slow.
+	webhookSlowRequestThreshold time.Duration
+
+	// minimumRSAKeySize is the size, in bits, of an RSA key below which it
+	// is counted as weak.
+	minimumRSAKeySize int
+
+	// onIssuance is called whenever an issuance metric is updated.
+	onIssuance func(IssuanceEvent)
+
+	// tokenReviewAuthentication, if set, requires scrapes of the metrics
+	// endpoint to present a bearer token authenticated by the API server.
+	tokenReviewAuthentication *TokenReviewAuthentication
+}
+
+// WithNamespace sets the namespace which prefixes the names of all metrics,
+// e.g. `myorg` exposes `certmanager_certificate_ready_status` as
+// `myorg_certificate_ready_status`. Defaults to `certmanager`. Names passed
+// to WithSummaryObjectives and WithHistogramBuckets must use the configured
+// namespace. The controller and webhook always use the default.
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithIdleTimeout sets the maximum amount of time the metrics server will
+// wait for the next request when keep-alives are enabled. Defaults to 120s.
+// The controller and webhook do not expose a flag for this and always serve
+// metrics with the default.
+func WithIdleTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.idleTimeout = timeout
+	}
+}
+
+// WithCertificateReadyStatusReason adds a `reason` label to the
+// certificate_ready_status metric, containing the reason of the Certificate's
+// Ready condition. This is disabled by default as it increases the number of
+// series exposed for each Certificate.
+func WithCertificateReadyStatusReason(enabled bool) Option {
+	return func(o *options) {
+		o.certificateReadyStatusReason = enabled
+	}
+}
+
+// WithVaultIssuanceLabels sets whether the vault_issuance_count metric is
+// labelled with the Vault PKI role and path used to sign each certificate.
+// This is enabled by default, and can be disabled where there are many
+// distinct roles or paths.
+func WithVaultIssuanceLabels(enabled bool) Option {
+	return func(o *options) {
+		o.disableVaultIssuanceLabels = !enabled
+	}
+}
+
+// WithZeroValuedSeries pre-populates counters with zero-valued series for
+// known label values, so that they are exposed before they are first
+// incremented. The per-controller counters are pre-populated for the given
+// controller names. This is disabled by default, as label values which are
+// never used would otherwise be exposed.
+func WithZeroValuedSeries(controllers ...string) Option {
+	return func(o *options) {
+		o.zeroValuedSeries = true
+		o.zeroValuedSeriesControllers = controllers
+	}
+}
+
+// WithSummaryObjectives sets the quantile objectives, mapped to their
+// allowed absolute error, of the summary with the given fully-qualified
+// name, e.g. `certmanager_http_venafi_client_request_duration_seconds`.
+// Summaries without objectives set use defaultSummaryObjectives. This is not
+// configurable in the controller or webhook, which always use the defaults.
+func WithSummaryObjectives(metric string, objectives map[float64]float64) Option {
+	return func(o *options) {
+		if o.summaryObjectives == nil {
+			o.summaryObjectives = make(map[string]map[float64]float64)
+		}
+		o.summaryObjectives[metric] = objectives
+	}
+}
+
+// WithHistogramBuckets sets the upper bounds of the buckets of the histogram
+// with the given fully-qualified name, e.g.
+// `certmanager_http_acme_client_request_duration_seconds_histogram`.
+// Histograms without buckets set use their default buckets.
+func WithHistogramBuckets(metric string, buckets []float64) Option {
+	return func(o *options) {
+		if o.histogramBuckets == nil {
+			o.histogramBuckets = make(map[string][]float64)
+		}
+		o.histogramBuckets[metric] = buckets
+	}
+}
+
+// WithAdminEndpoints serves the admin endpoints on the metrics server, which
+// allow individual metrics to be disabled and re-enabled at runtime. Since
+// disabling a metric can silence alerts, every request must be both
+// authenticated, in the same way as scrapes, and authorized by a
+// SubjectAccessReview for the `post` verb on the request path, e.g.
+// `/admin/metrics/certmanager_certificate_ready_status/disable`. The admin
+// endpoints are therefore only served if WithTokenReviewAuthentication is
+// also used. The admin endpoints are not served by default.
+func WithAdminEndpoints(subjectAccessReviews authorizationv1client.SubjectAccessReviewInterface) Option {
+	return func(o *options) {
+		o.adminSubjectAccessReviews = subjectAccessReviews
+	}
+}
+
+// WithUTF8MetricNames exposes metrics with dot-separated UTF-8 metric names,
+// e.g. `certmanager_certificate_ready_status` is exposed as
+// `certmanager.certificate.ready.status`. This is only honoured when the
+// Prometheus client library in use accepts UTF-8 metric names; otherwise a
+// message is logged and underscore-separated names are used. This is disabled
+// by default, and the controller and webhook do not enable it.
+func WithUTF8MetricNames(enabled bool) Option {
+	return func(o *options) {
+		o.utf8MetricNames = enabled
+	}
+}
+
+// WithWebhookSlowRequestThreshold sets the duration after which a webhook
+// request is counted in the webhook_slow_request_count metric. Defaults to
+// 1s.
+func WithWebhookSlowRequestThreshold(threshold time.Duration) Option {
+	return func(o *options) {
+		o.webhookSlowRequestThreshold = threshold
+	}
+}
+
+// WithMinimumRSAKeySize sets the size, in bits, of an RSA key below which a
+// Certificate is counted in the certificate_weak_key_count metric. Defaults
+// to 2048.
+func WithMinimumRSAKeySize(size int) Option {
+	return func(o *options) {
+		o.minimumRSAKeySize = size
+	}
+}
+
+// WithOnIssuance sets a callback which is called whenever an issuance metric
+// is updated, so that issuances can be forwarded to sinks other than
+// Prometheus. The callback is run in its own goroutine and must be safe for
+// concurrent use; events may be delivered out of order.
+func WithOnIssuance(fn func(event IssuanceEvent)) Option {
+	return func(o *options) {
+		o.onIssuance = fn
+	}
+}
+
+// WithTokenReviewAuthentication requires scrapes of the /metrics endpoint of
+// the metrics server to present a bearer token, which is validated using the
+// Kubernetes TokenReview API. Scrapes with a missing or invalid token are
+// refused. Scrapes are not authenticated by default.
+func WithTokenReviewAuthentication(authn TokenReviewAuthentication) Option {
+	return func(o *options) {
+		o.tokenReviewAuthentication = &authn
+	}
+}
+
+// objectivesFor returns the quantile objectives of the summary with the given
+// fully-qualified name.
+func (o options) objectivesFor(metric string) map[float64]float64 {
+	if objectives, ok := o.summaryObjectives[metric]; ok {
+		return objectives
+	}
+	return defaultSummaryObjectives
+}
+
+// bucketsFor returns the buckets of the histogram with the given
+// fully-qualified name, or defaults if none were set.
+func (o options) bucketsFor(metric string, defaults []float64) []float64 {
+	if buckets, ok := o.histogramBuckets[metric]; ok {
+		return buckets
+	}
+	return defaults
+}
+
 // Metrics is designed to be a shared object for updating the metrics exposed
 // by cert-manager
 type Metrics struct {
 	log      logr.Logger
 	registry *prometheus.Registry
+	clock    clock.Clock
+	opts     options
+
+	registerOnce sync.Once
+	// collectors are the registered collectors keyed by their
+	// fully-qualified metric name.
+	collectors map[string]prometheus.Collector
+	// webhook is true if only the metrics recorded by the webhook are
+	// registered. Otherwise, the metrics recorded only by the webhook are
+	// not registered.
+	webhook bool
 
-	clockTimeSeconds                   prometheus.CounterFunc
-	clockTimeSecondsGauge              prometheus.GaugeFunc
-	certificateExpiryTimeSeconds       *prometheus.GaugeVec
-	certificateRenewalTimeSeconds      *prometheus.GaugeVec
-	certificateReadyStatus             *prometheus.GaugeVec
-	acmeClientRequestDurationSeconds   *prometheus.SummaryVec
-	acmeClientRequestCount             *prometheus.CounterVec
-	venafiClientRequestDurationSeconds *prometheus.SummaryVec
-	controllerSyncCallCount            *prometheus.CounterVec
-	controllerSyncErrorCount           *prometheus.CounterVec
+	// resyncSeriesLock guards resyncSeries, the label values of the series
+	// currently set on each GaugeVec recomputed on resync.
+	resyncSeriesLock sync.Mutex
+	resyncSeries     map[*prometheus.GaugeVec]map[string][]string
+
+	clockTimeSeconds                             prometheus.CounterFunc
+	clockTimeSecondsGauge                        prometheus.GaugeFunc
+	certificateExpiryTimeSeconds                 *prometheus.GaugeVec
+	certificateRenewalTimeSeconds                *prometheus.GaugeVec
+	certificateReadyStatus                       *prometheus.GaugeVec
+	acmeClientRequestDurationSeconds             *prometheus.SummaryVec
+	acmeClientRequestCount                       *prometheus.CounterVec
+	venafiClientRequestDurationSeconds           *prometheus.SummaryVec
+	controllerSyncCallCount                      *prometheus.CounterVec
+	controllerSyncErrorCount                     *prometheus.CounterVec
+	certificateEmptyIssuerGroupCount             *prometheus.GaugeVec
+	certificateRequestPolicyDecisionCount        *prometheus.CounterVec
+	certificateUpcomingRenewals                  *prometheus.GaugeVec
+	certificateSecretParseErrorCount             *prometheus.CounterVec
+	controllerWorkqueueLatencySeconds            *prometheus.HistogramVec
+	certificateExternalIssuerCount               *prometheus.GaugeVec
+	webhookCertLastReloadTimestampSeconds        prometheus.Gauge
+	certificateDistinctIssuersInChain            *prometheus.GaugeVec
+	vaultIssuanceCount                           *prometheus.CounterVec
+	acmeDNS01RateLimitedCount                    *prometheus.CounterVec
+	certificateTimeToExpiryBucketCount           *prometheus.GaugeVec
+	controllerNoopReconcileCount                 *prometheus.CounterVec
+	shimAnnotationConflictCount                  *prometheus.GaugeVec
+	certificateOrphanedSecretCount               *prometheus.GaugeVec
+	acmeHTTP01SelfCheckResponseCodeCount         *prometheus.CounterVec
+	webhookRequestCount                          *prometheus.CounterVec
+	loggingVerbosityLevel                        prometheus.Gauge
+	certificateIssuerSelectorMismatchCount       *prometheus.GaugeVec
+	webhookValidationRulesEvaluated              *prometheus.HistogramVec
+	certificateKeyCertMismatchCount              *prometheus.GaugeVec
+	metricsScrapeCount                           *prometheus.CounterVec
+	certificateReconcileErrorCount               *prometheus.CounterVec
+	watchedSecretCount                           prometheus.Gauge
+	certificateRenewalRescheduleCount            *prometheus.CounterVec
+	acmeClientProblemCount                       *prometheus.CounterVec
+	certificateSecretMultiManagedCount           *prometheus.GaugeVec
+	certificateInBackoffCount                    *prometheus.GaugeVec
+	certificateRequestRequestorCount             *prometheus.GaugeVec
+	certificateIssuedCount                       *prometheus.CounterVec
+	webhookPanicRecoveredCount                   *prometheus.CounterVec
+	certificateRenewalIdenticalCount             *prometheus.CounterVec
+	metricsTLSHandshakeDurationSeconds           prometheus.Histogram
+	certificateInvalidDurationConfigCount        *prometheus.GaugeVec
+	issuerQuotaExceededCount                     *prometheus.CounterVec
+	certificateCrossNamespaceSecretRefCount      *prometheus.GaugeVec
+	conversionRequestObjectBytes                 *prometheus.HistogramVec
+	certificateSANTypeCount                      *prometheus.GaugeVec
+	certificateBlockedByNotReadyIssuerCount      *prometheus.GaugeVec
+	webhookSlowRequestCount                      *prometheus.CounterVec
+	metricsServerBindErrorCount                  prometheus.Counter
+	certificateWeakKeyCount                      *prometheus.GaugeVec
+	certificateMissingCACrtCount                 *prometheus.GaugeVec
+	distinctIssuerRefCount                       prometheus.Gauge
+	certificateAgeBucketCount                    *prometheus.GaugeVec
+	metricsCertificateRequestListSize            prometheus.Gauge
+	certificatePendingCount                      *prometheus.GaugeVec
+	acmeAuthorizationReusedCount                 *prometheus.CounterVec
+	certificateImmutableSecretCount              *prometheus.GaugeVec
+	controllerWorkersBusy                        *prometheus.GaugeVec
+	controllerWorkersTotal                       *prometheus.GaugeVec
+	acmeNewOrderDurationSeconds                  *prometheus.HistogramVec
+	certificateNeedsInterventionCount            *prometheus.GaugeVec
+	webhookServingCertRotationCount              prometheus.Counter
+	webhookServingCertExpirationTimestampSeconds prometheus.Gauge
+	certificateKeystorePasswordMissingCount      *prometheus.GaugeVec
+	controllerResyncObjectCount                  *prometheus.GaugeVec
+	acmeChallengeCleanupLagSeconds               *prometheus.HistogramVec
+	certificateTriggeredRequestCount             *prometheus.CounterVec
+	webhookSNIMismatchCount                      prometheus.Counter
+	certificateChainExpiringSoonCount            *prometheus.GaugeVec
+	secretWatchEventCount                        *prometheus.CounterVec
+	certificateSubjectFieldCount                 *prometheus.GaugeVec
+	certificateRequestApprovalDurationSeconds    *prometheus.HistogramVec
+	certificateGatedFeatureBlockedCount          *prometheus.GaugeVec
+	acmeClientRequestsInFlight                   *prometheus.GaugeVec
+	webhookTLSNegotiatedCount                    *prometheus.CounterVec
+	certificateManualRenewalCount                *prometheus.CounterVec
+	certificateIssuanceDurationSeconds           *prometheus.HistogramVec
+	acmeClientRequestDurationSecondsHistogram    *prometheus.HistogramVec
+	certificateIssuerRefDriftCount               *prometheus.GaugeVec
+	venafiClientRequestCount                     *prometheus.CounterVec
+	acmeOrderFinalizeDurationSeconds             *prometheus.HistogramVec
+	shimMissingCertificateCount                  *prometheus.GaugeVec
+	metricsRequestTimeoutCount                   prometheus.Counter
 }
 
 var readyConditionStatuses = [...]cmmeta.ConditionStatus{cmmeta.ConditionTrue, cmmeta.ConditionFalse, cmmeta.ConditionUnknown}
 
+// overdueRenewalWindow is the window of the certificate_upcoming_renewals
+// series which counts the Certificates whose renewal time has passed.
+const overdueRenewalWindow = "overdue"
+
+// upcomingRenewalWindows are the windows used for the
+// certificate_upcoming_renewals metric.
+var upcomingRenewalWindows = [...]struct {
+	label    string
+	duration time.Duration
+}{
+	{label: "1h", duration: time.Hour},
+	{label: "24h", duration: 24 * time.Hour},
+	{label: "7d", duration: 7 * 24 * time.Hour},
+}
+
+// defaultSummaryObjectives are the quantile objectives of summaries which
+// have not been configured using WithSummaryObjectives.
+var defaultSummaryObjectives = map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001}
+
+// timeToExpiryBuckets are the buckets used for the
+// certificate_time_to_expiry_bucket_count and certificate_age_bucket_count
+// metrics, from one hour to one year.
+var timeToExpiryBuckets = []float64{
+	time.Hour.Seconds(),
+	(6 * time.Hour).Seconds(),
+	(24 * time.Hour).Seconds(),
+	(7 * 24 * time.Hour).Seconds(),
+	(14 * 24 * time.Hour).Seconds(),
+	(30 * 24 * time.Hour).Seconds(),
+	(60 * 24 * time.Hour).Seconds(),
+	(90 * 24 * time.Hour).Seconds(),
+	(180 * 24 * time.Hour).Seconds(),
+	(365 * 24 * time.Hour).Seconds(),
+}
+
 // New creates a Metrics struct and populates it with prometheus metric types.
-func New(log logr.Logger, c clock.Clock) *Metrics {
+// The metrics recorded only by the webhook are not registered; the webhook
+// creates its Metrics with NewWebhook instead.
+func New(log logr.Logger, c clock.Clock, opts ...Option) *Metrics {
+	o := options{
+		namespace:                   defaultNamespace,
+		idleTimeout:                 prometheusMetricsServerIdleTimeout,
+		webhookSlowRequestThreshold: defaultWebhookSlowRequestThreshold,
+		minimumRSAKeySize:           defaultMinimumRSAKeySize,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.utf8MetricNames && !utf8MetricNamesSupported() {
+		log.Info("UTF-8 metric names are not supported by this version of the Prometheus client library, using underscore-separated metric names")
+		o.utf8MetricNames = false
+	}
+	namespace := o.namespace
+
+	certificateReadyStatusLabels := []string{"name", "namespace", "condition", "issuer_name", "issuer_kind", "issuer_group", "issuer_namespace"}
+	if o.certificateReadyStatusReason {
+		certificateReadyStatusLabels = append(certificateReadyStatusLabels, "reason")
+	}
+
 	var (
 		// Deprecated in favour of clock_time_seconds_gauge.
 		clockTimeSeconds = prometheus.NewCounterFunc(
@@ -106,7 +475,7 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 				Name:      "certificate_expiration_timestamp_seconds",
 				Help:      "The date after which the certificate expires. Expressed as a Unix Epoch Time.",
 			},
-			[]string{"name", "namespace", "issuer_name", "issuer_kind", "issuer_group"},
+			[]string{"name", "namespace", "issuer_name", "issuer_kind", "issuer_group", "issuer_namespace"},
 		)
 
 		certificateRenewalTimeSeconds = prometheus.NewGaugeVec(
@@ -115,7 +484,7 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 				Name:      "certificate_renewal_timestamp_seconds",
 				Help:      "The number of seconds before expiration time the certificate should renew.",
 			},
-			[]string{"name", "namespace", "issuer_name", "issuer_kind", "issuer_group"},
+			[]string{"name", "namespace", "issuer_name", "issuer_kind", "issuer_group", "issuer_namespace"},
 		)
 
 		certificateReadyStatus = prometheus.NewGaugeVec(
@@ -124,7 +493,7 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 				Name:      "certificate_ready_status",
 				Help:      "The ready status of the certificate.",
 			},
-			[]string{"name", "namespace", "condition", "issuer_name", "issuer_kind", "issuer_group"},
+			certificateReadyStatusLabels,
 		)
 
 		// acmeClientRequestCount is a Prometheus summary to collect the number of
@@ -141,13 +510,14 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 
 		// acmeClientRequestDurationSeconds is a Prometheus summary to collect request
 		// times for the ACME client.
+		// Deprecated in favour of acmeClientRequestDurationSecondsHistogram.
 		acmeClientRequestDurationSeconds = prometheus.NewSummaryVec(
 			prometheus.SummaryOpts{
 				Namespace:  namespace,
 				Name:       "acme_client_request_duration_seconds",
-				Help:       "The HTTP request latencies in seconds for the ACME client.",
+				Help:       "DEPRECATED: use http_acme_client_request_duration_seconds_histogram instead. The HTTP request latencies in seconds for the ACME client.",
 				Subsystem:  "http",
-				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+				Objectives: o.objectivesFor(namespace + "_http_acme_client_request_duration_seconds"),
 			},
 			[]string{"scheme", "host", "path", "method", "status"},
 		)
@@ -162,7 +532,7 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 				Name:       "venafi_client_request_duration_seconds",
 				Help:       "ALPHA: The HTTP request latencies in seconds for the Venafi client. This metric is currently alpha as we would like to understand whether it helps to measure Venafi call latency. Please leave feedback if you have any.",
 				Subsystem:  "http",
-				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+				Objectives: o.objectivesFor(namespace + "_http_venafi_client_request_duration_seconds"),
 			},
 			[]string{"api_call"},
 		)
@@ -184,23 +554,838 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			},
 			[]string{"controller"},
 		)
+
+		// certificateEmptyIssuerGroupCount is recomputed on each resync. An
+		// empty issuer group usually indicates a manifest written before the
+		// group was commonly set explicitly.
+		certificateEmptyIssuerGroupCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_empty_issuer_group_count",
+				Help:      "The number of Certificates with an empty issuerRef group.",
+			},
+			[]string{"namespace"},
+		)
+
+		// certificateRequestPolicyDecisionCount counts the approval decisions
+		// made for CertificateRequests, labelled by the policy which made them
+		// and whether it was cert-manager's internal approver, a policy
+		// approver or a manual decision.
+		certificateRequestPolicyDecisionCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "certificaterequest_policy_decision_count",
+				Help:      "The number of approval decisions made for CertificateRequests, by policy, decision and approval source.",
+			},
+			[]string{"policy", "decision", "approval_source"},
+		)
+
+		// certificateUpcomingRenewals is recomputed on each resync. Windows
+		// are cumulative, so a Certificate renewing within the next hour is
+		// also counted in the 24h and 7d windows, and an overdue Certificate
+		// is counted in every window.
+		certificateUpcomingRenewals = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_upcoming_renewals",
+				Help:      "The number of Certificates due to be renewed within the given window, including those overdue for renewal. The overdue window only counts those overdue for renewal.",
+			},
+			[]string{"window"},
+		)
+
+		// certificateSecretParseErrorCount counts the Secrets whose tls.crt
+		// could not be decoded while computing metrics on resync.
+		certificateSecretParseErrorCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "certificate_secret_parse_error_count",
+				Help:      "The number of times a Certificate's Secret could not be parsed while computing metrics.",
+			},
+			[]string{"namespace"},
+		)
+
+		// controllerWorkqueueLatencySeconds is observed when an item is taken
+		// off a controller's workqueue, measuring how long it was waiting.
+		controllerWorkqueueLatencySeconds = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "controller_workqueue_latency_seconds",
+				Help:      "The time in seconds an item stays in a controller's workqueue before being processed.",
+				Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+			},
+			[]string{"controller"},
+		)
+
+		// certificateExternalIssuerCount is recomputed on each resync.
+		certificateExternalIssuerCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_external_issuer_count",
+				Help:      "The number of Certificates which reference an external issuer, i.e. one outside of the cert-manager.io group.",
+			},
+			[]string{"issuer_group", "issuer_kind"},
+		)
+
+		// webhookCertLastReloadTimestampSeconds is set each time the webhook
+		// successfully loads a new serving certificate.
+		webhookCertLastReloadTimestampSeconds = prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "webhook_cert_last_reload_timestamp_seconds",
+				Help:      "The time at which the webhook last reloaded its serving certificate. Expressed as a Unix Epoch Time.",
+			},
+		)
+
+		// certificateDistinctIssuersInChain is recomputed on each resync. A
+		// change in value indicates the Certificate is now chaining through a
+		// different CA.
+		certificateDistinctIssuersInChain = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_distinct_issuers_in_chain",
+				Help:      "The number of distinct issuers in the certificate chain and CA stored in a Certificate's Secret.",
+			},
+			[]string{"name", "namespace"},
+		)
+
+		// vaultIssuanceCount is incremented each time the Vault issuer
+		// attempts to sign a certificate.
+		vaultIssuanceCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "vault_issuance_count",
+				Help:      "The number of certificates signed by Vault, by PKI role, path and result.",
+			},
+			[]string{"role", "path", "result"},
+		)
+
+		// acmeDNS01RateLimitedCount is incremented each time a DNS01 provider
+		// returns an error indicating that it is rate limiting requests.
+		acmeDNS01RateLimitedCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "acme_dns01_rate_limited_count",
+				Help:      "The number of times a DNS01 provider returned a rate limit error while presenting or cleaning up a challenge record.",
+			},
+			[]string{"provider"},
+		)
+
+		// certificateTimeToExpiryBucketCount is recomputed on each resync,
+		// giving the distribution of expiry times without a series per
+		// Certificate. It is a gauge rather than a histogram, as a histogram
+		// cannot be recomputed from scratch without resetting it, so it is not
+		// named like one.
+		certificateTimeToExpiryBucketCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_time_to_expiry_bucket_count",
+				Help:      "The number of Certificates which expire within le seconds, as of the last resync. Expired Certificates are counted in every bucket. This is a gauge, not a histogram.",
+			},
+			[]string{"issuer_kind", "le"},
+		)
+
+		controllerNoopReconcileCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "controller_noop_reconcile_count",
+				Help:      "The number of reconciles of each controller which determined that nothing needed to be done.",
+			},
+			[]string{"controller"},
+		)
+
+		// shimAnnotationConflictCount is recomputed on each resync.
+		shimAnnotationConflictCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "shim_annotation_conflict_count",
+				Help:      "The number of annotated Ingress TLS entries whose Certificate already exists and is not owned by the Ingress, so is not managed by ingress-shim.",
+			},
+			[]string{"namespace"},
+		)
+
+		// certificateOrphanedSecretCount is recomputed on each resync.
+		certificateOrphanedSecretCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_orphaned_secret_count",
+				Help:      "The number of Secrets managed by cert-manager which are not referenced or owned by any Certificate.",
+			},
+			[]string{"namespace"},
+		)
+
+		acmeHTTP01SelfCheckResponseCodeCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "acme_http01_selfcheck_response_code_count",
+				Help:      "The number of HTTP01 self check responses, by HTTP status code.",
+			},
+			[]string{"code"},
+		)
+
+		// webhookRequestCount is incremented for each request received by
+		// the webhook, labelled by the normalized User-Agent of the caller.
+		webhookRequestCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "webhook_request_count",
+				Help:      "The number of requests received by the webhook, by path and the major and minor version of the caller's User-Agent.",
+			},
+			[]string{"path", "user_agent"},
+		)
+
+		// loggingVerbosityLevel is set once at startup from the logging
+		// configuration.
+		loggingVerbosityLevel = prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "logging_verbosity_level",
+				Help:      "The log verbosity level the component was started with.",
+			},
+		)
+
+		// certificateIssuerSelectorMismatchCount is recomputed on each resync.
+		certificateIssuerSelectorMismatchCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_issuer_selector_mismatch_count",
+				Help:      "The number of Certificates referencing an Issuer which only exists outside of their namespace, or as a ClusterIssuer.",
+			},
+			[]string{"namespace"},
+		)
+
+		webhookValidationRulesEvaluated = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "webhook_validation_rules_evaluated",
+				Help:      "The number of validation rules evaluated by the webhook for each admission request.",
+				Buckets:   prometheus.LinearBuckets(1, 1, 10),
+			},
+			[]string{"resource"},
+		)
+
+		// certificateKeyCertMismatchCount is recomputed on each resync.
+		certificateKeyCertMismatchCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_key_cert_mismatch_count",
+				Help:      "Whether the private key stored in a Certificate's Secret does not match the public key of its certificate. Only mismatched Certificates are exposed.",
+			},
+			[]string{"namespace", "name"},
+		)
+
+		metricsScrapeCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "metrics_scrape_count",
+				Help:      "The number of requests served by the metrics endpoint, by the class of the scraper's IP address.",
+			},
+			[]string{"source"},
+		)
+
+		certificateReconcileErrorCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "certificate_reconcile_error_count",
+				Help:      "The number of errors encountered while reconciling Certificates, by the issuer they reference and the reason for the error.",
+			},
+			[]string{"issuer_name", "issuer_kind", "issuer_group", "reason"},
+		)
+
+		// watchedSecretCount is recomputed on each resync.
+		watchedSecretCount = prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "watched_secret_count",
+				Help:      "The number of Secrets held in the controller's Secret informer cache.",
+			},
+		)
+
+		certificateRenewalRescheduleCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "certificate_renewal_reschedule_count",
+				Help:      "The number of times the renewal of a Certificate was rescheduled without a new certificate being issued, or was moved earlier.",
+			},
+			[]string{"issuer_kind"},
+		)
+
+		acmeClientProblemCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "acme_client_problem_count",
+				Help:      "The number of problem documents returned by ACME servers, by the type of the problem.",
+			},
+			[]string{"host", "problem_type"},
+		)
+
+		// certificateSecretMultiManagedCount is recomputed on each resync.
+		certificateSecretMultiManagedCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_secret_multimanaged_count",
+				Help:      "The number of Certificates' Secrets whose certificate or private key data is also managed by a field manager other than cert-manager.",
+			},
+			[]string{"namespace"},
+		)
+
+		// certificateInBackoffCount is recomputed on each resync.
+		certificateInBackoffCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_in_backoff_count",
+				Help:      "The number of Certificates backing off from re-issuance after a failed issuance attempt.",
+			},
+			[]string{"issuer_kind", "issuer_group"},
+		)
+
+		// certificateRequestRequestorCount is recomputed on each resync.
+		certificateRequestRequestorCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificaterequest_requestor_count",
+				Help:      "The number of CertificateRequests by the identity which created them. ServiceAccounts are grouped by namespace, and all other users are grouped together.",
+			},
+			[]string{"requestor"},
+		)
+
+		certificateIssuedCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "certificate_issued_count",
+				Help:      "The number of certificates successfully issued, by whether the CSR was generated by cert-manager for a Certificate or provided by a client.",
+			},
+			[]string{"issuer_kind", "issuer_group", "csr_source"},
+		)
+
+		webhookPanicRecoveredCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "webhook_panic_recovered_count",
+				Help:      "The number of panics recovered while handling webhook requests, by the handler which panicked.",
+			},
+			[]string{"handler"},
+		)
+
+		certificateRenewalIdenticalCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "certificate_renewal_identical_count",
+				Help:      "The number of issuances which produced a certificate identical to the one issued for the Certificate's previous revision.",
+			},
+			[]string{"issuer_kind"},
+		)
+
+		metricsTLSHandshakeDurationSeconds = prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "metrics_tls_handshake_duration_seconds",
+				Help:      "The duration in seconds of TLS handshakes with clients of a TLS metrics server.",
+				Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+			},
+		)
+
+		// certificateInvalidDurationConfigCount is recomputed on each resync.
+		certificateInvalidDurationConfigCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_invalid_duration_config_count",
+				Help:      "The number of Certificates whose renewBefore is greater than or equal to their duration, causing them to be renewed continuously.",
+			},
+			[]string{"namespace"},
+		)
+
+		issuerQuotaExceededCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "issuer_quota_exceeded_count",
+				Help:      "The number of signing attempts which failed because the CA reported that a quota was exceeded.",
+			},
+			[]string{"issuer_name", "issuer_kind", "issuer_group"},
+		)
+
+		// certificateCrossNamespaceSecretRefCount is recomputed on each
+		// resync.
+		certificateCrossNamespaceSecretRefCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_cross_namespace_secret_ref_count",
+				Help:      "The number of Certificates issued using Secrets in another namespace, such as those of a ClusterIssuer, by the Certificate's namespace and the Secrets' namespace.",
+			},
+			[]string{"source_namespace", "target_namespace"},
+		)
+
+		conversionRequestObjectBytes = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "conversion_request_object_bytes",
+				Help:      "The size in bytes of the serialized objects converted by the conversion webhook, by kind.",
+				Buckets:   prometheus.ExponentialBuckets(256, 2, 12),
+			},
+			[]string{"kind"},
+		)
+
+		// certificateSANTypeCount is recomputed on each resync.
+		certificateSANTypeCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_san_type_count",
+				Help:      "The number of subject alternative names requested across all Certificates, by SAN type.",
+			},
+			[]string{"san_type"},
+		)
+
+		// certificateBlockedByNotReadyIssuerCount is recomputed on each
+		// resync.
+		certificateBlockedByNotReadyIssuerCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_blocked_by_notready_issuer_count",
+				Help:      "The number of Certificates referencing an Issuer or ClusterIssuer which is not Ready.",
+			},
+			[]string{"issuer_name", "issuer_kind", "issuer_group"},
+		)
+
+		webhookSlowRequestCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "webhook_slow_request_count",
+				Help:      "The number of webhook requests which took longer than the slow request threshold to handle, by webhook path.",
+			},
+			[]string{"webhook"},
+		)
+
+		metricsServerBindErrorCount = prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "metrics_server_bind_error_count",
+				Help:      "The number of times the metrics server failed to listen on its configured address.",
+			},
+		)
+
+		certificateWeakKeyCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_weak_key_count",
+				Help:      "The number of Certificates whose issued certificate has a public key below the minimum strength for its algorithm, by algorithm and namespace.",
+			},
+			[]string{"algorithm", "namespace"},
+		)
+
+		certificateMissingCACrtCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_missing_ca_crt_count",
+				Help:      "The number of issued Certificates whose Secret does not contain a ca.crt, by issuer kind and group.",
+			},
+			[]string{"issuer_kind", "issuer_group"},
+		)
+
+		distinctIssuerRefCount = prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "distinct_issuerref_count",
+				Help:      "The number of distinct issuers referenced by Certificates.",
+			},
+		)
+
+		// certificateAgeBucketCount is recomputed on each resync. Like
+		// certificateTimeToExpiryBucketCount, it is a gauge so that it can be
+		// recomputed without resetting it.
+		certificateAgeBucketCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_age_bucket_count",
+				Help:      "The number of Ready Certificates which became valid within the last le seconds, as of the last resync. This is a gauge, not a histogram.",
+			},
+			[]string{"issuer_kind", "le"},
+		)
+
+		metricsCertificateRequestListSize = prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "metrics_certificaterequest_list_size",
+				Help:      "The number of CertificateRequests listed to compute the aggregate metrics on the last resync.",
+			},
+		)
+
+		certificatePendingCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_pending_count",
+				Help:      "The number of Certificates pending issuance, by phase (initial or renewal) and issuer kind.",
+			},
+			[]string{"phase", "issuer_kind"},
+		)
+
+		acmeAuthorizationReusedCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "acme_authorization_reused_count",
+				Help:      "The number of ACME authorizations which were already valid when their Order was created, so required no challenge to be solved.",
+			},
+			[]string{"issuer_name", "namespace"},
+		)
+
+		certificateImmutableSecretCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_immutable_secret_count",
+				Help:      "The number of Certificates' Secrets, by whether the Secret is immutable.",
+			},
+			[]string{"immutable"},
+		)
+
+		controllerWorkersBusy = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "controller_workers_busy",
+				Help:      "The number of a controller's workers which are currently processing an item.",
+			},
+			[]string{"controller"},
+		)
+
+		controllerWorkersTotal = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "controller_workers_total",
+				Help:      "The number of workers started by a controller.",
+			},
+			[]string{"controller"},
+		)
+
+		acmeNewOrderDurationSeconds = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "acme_new_order_duration_seconds",
+				Help:      "The time taken to create a new order with an ACME server, by ACME server host.",
+				Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+			},
+			[]string{"host"},
+		)
+
+		certificateNeedsInterventionCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_needs_intervention_count",
+				Help:      "The number of Certificates which are not Ready and whose latest CertificateRequest is in a terminal state that will not resolve without intervention, by reason and issuer kind.",
+			},
+			[]string{"reason", "issuer_kind"},
+		)
+
+		webhookServingCertRotationCount = prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "webhook_serving_cert_rotation_count",
+				Help:      "The number of times the webhook has generated a new in-memory serving certificate.",
+			},
+		)
+
+		webhookServingCertExpirationTimestampSeconds = prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "webhook_serving_cert_expiration_timestamp_seconds",
+				Help:      "The date after which the webhook's current in-memory serving certificate expires. Expressed as a Unix Epoch Time.",
+			},
+		)
+
+		certificateKeystorePasswordMissingCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_keystore_password_missing_count",
+				Help:      "The number of Certificates which request a JKS or PKCS#12 keystore but whose password Secret, or the key within it, does not exist.",
+			},
+			[]string{"namespace"},
+		)
+
+		controllerResyncObjectCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "controller_resync_object_count",
+				Help:      "The number of objects processed by the most recent full resync of a controller.",
+			},
+			[]string{"controller"},
+		)
+
+		acmeChallengeCleanupLagSeconds = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "acme_challenge_cleanup_lag_seconds",
+				Help:      "The time between an ACME challenge becoming valid and the resources created to solve it being cleaned up, by challenge type.",
+				Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+			},
+			[]string{"type"},
+		)
+
+		certificateTriggeredRequestCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "certificate_triggered_request_count",
+				Help:      "The number of CertificateRequests created while reconciling Certificates, by the kind of issuer referenced.",
+			},
+			[]string{"issuer_kind"},
+		)
+
+		webhookSNIMismatchCount = prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "webhook_sni_mismatch_count",
+				Help:      "The number of TLS handshakes with the webhook which requested a server name not covered by its serving certificate.",
+			},
+		)
+
+		certificateChainExpiringSoonCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_chain_expiring_soon_count",
+				Help:      "The number of Certificates whose Secret contains a CA certificate which expires within 30 days, by the kind and group of their issuer.",
+			},
+			[]string{"issuer_kind", "issuer_group"},
+		)
+
+		secretWatchEventCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "secret_watch_event_count",
+				Help:      "The number of add, update and delete events processed by the controller's Secret informer.",
+			},
+			[]string{"event_type"},
+		)
+
+		// certificateSubjectFieldCount is recomputed on each resync.
+		certificateSubjectFieldCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_subject_field_count",
+				Help:      "The number of Certificates which set each subject field other than the common name.",
+			},
+			[]string{"field"},
+		)
+
+		certificateRequestApprovalDurationSeconds = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "certificaterequest_approval_duration_seconds",
+				Help:      "The time between a CertificateRequest being created and being approved, by the kind and group of the issuer referenced.",
+				// Approval may be manual, so the buckets range from a second
+				// to around three days.
+				Buckets: prometheus.ExponentialBuckets(1, 4, 10),
+			},
+			[]string{"issuer_kind", "issuer_group"},
+		)
+
+		// certificateGatedFeatureBlockedCount is recomputed on each resync.
+		certificateGatedFeatureBlockedCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_gated_feature_blocked_count",
+				Help:      "The number of Certificates which use a field that is ignored because the feature gate it requires is disabled.",
+			},
+			[]string{"feature"},
+		)
+
+		acmeClientRequestsInFlight = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "acme_client_requests_in_flight",
+				Help:      "The number of HTTP requests to ACME servers which are currently in flight, by host.",
+			},
+			[]string{"host"},
+		)
+
+		webhookTLSNegotiatedCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "webhook_tls_negotiated_count",
+				Help:      "The number of TLS handshakes with the webhook, by the negotiated TLS version and cipher suite.",
+			},
+			[]string{"tls_version", "cipher"},
+		)
+
+		certificateManualRenewalCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "certificate_manual_renewal_count",
+				Help:      "The number of Certificate re-issuances manually triggered by an operator, e.g. using `cmctl renew`.",
+			},
+			[]string{"namespace"},
+		)
+
+		certificateIssuanceDurationSeconds = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "certificate_issuance_duration_seconds",
+				Help:      "The time between a Certificate being created and first becoming Ready, by the issuer referenced.",
+				// Issuance may require solving ACME challenges, so the
+				// buckets range from a second to a little over two hours.
+				Buckets: prometheus.ExponentialBuckets(1, 2, 14),
+			},
+			[]string{"issuer_name", "issuer_kind", "issuer_group"},
+		)
+
+		// acmeClientRequestDurationSecondsHistogram replaces the
+		// acmeClientRequestDurationSeconds summary, whose quantiles cannot be
+		// aggregated across replicas. Both are observed until the summary is
+		// removed.
+		acmeClientRequestDurationSecondsHistogram = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Subsystem: "http",
+				Name:      "acme_client_request_duration_seconds_histogram",
+				Help:      "The HTTP request latencies in seconds for the ACME client.",
+				Buckets:   o.bucketsFor(namespace+"_http_acme_client_request_duration_seconds_histogram", prometheus.ExponentialBuckets(0.01, 2, 12)),
+			},
+			[]string{"scheme", "host", "path", "method", "status"},
+		)
+
+		// certificateIssuerRefDriftCount is recomputed on each resync.
+		certificateIssuerRefDriftCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_issuerref_drift_count",
+				Help:      "The number of Certificates whose Secret was issued by a different issuer than the one currently referenced by the Certificate.",
+			},
+			[]string{"namespace"},
+		)
+
+		venafiClientRequestCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: "http",
+				Name:      "venafi_client_request_count",
+				Help:      "The number of requests made by the Venafi client, by API call and status.",
+			},
+			[]string{"api_call", "status"},
+		)
+
+		acmeOrderFinalizeDurationSeconds = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "acme_order_finalize_duration_seconds",
+				Help:      "The time taken to finalize an ACME order and download the issued certificate, by ACME server host.",
+				// Finalization waits for the ACME server to issue the
+				// certificate, so the buckets range from 100ms to a
+				// little over three minutes.
+				Buckets: prometheus.ExponentialBuckets(0.1, 2, 12),
+			},
+			[]string{"host"},
+		)
+
+		// shimMissingCertificateCount is recomputed on each resync.
+		shimMissingCertificateCount = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "shim_missing_certificate_count",
+				Help:      "The number of Certificates requested by annotated Ingresses and Gateways which do not exist, by the namespace they are expected in.",
+			},
+			[]string{"namespace"},
+		)
+
+		metricsRequestTimeoutCount = prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "metrics_request_timeout_count",
+				Help:      "The number of requests to the metrics endpoint which were cut off by the metrics server's write timeout.",
+			},
+		)
 	)
 
 	// Create server and register Prometheus metrics handler
 	m := &Metrics{
 		log:      log.WithName("metrics"),
 		registry: prometheus.NewRegistry(),
+		clock:    c,
+		opts:     o,
 
-		clockTimeSeconds:                   clockTimeSeconds,
-		clockTimeSecondsGauge:              clockTimeSecondsGauge,
-		certificateExpiryTimeSeconds:       certificateExpiryTimeSeconds,
-		certificateRenewalTimeSeconds:      certificateRenewalTimeSeconds,
-		certificateReadyStatus:             certificateReadyStatus,
-		acmeClientRequestCount:             acmeClientRequestCount,
-		acmeClientRequestDurationSeconds:   acmeClientRequestDurationSeconds,
-		venafiClientRequestDurationSeconds: venafiClientRequestDurationSeconds,
-		controllerSyncCallCount:            controllerSyncCallCount,
-		controllerSyncErrorCount:           controllerSyncErrorCount,
+		resyncSeries: make(map[*prometheus.GaugeVec]map[string][]string),
+
+		clockTimeSeconds:                             clockTimeSeconds,
+		clockTimeSecondsGauge:                        clockTimeSecondsGauge,
+		certificateExpiryTimeSeconds:                 certificateExpiryTimeSeconds,
+		certificateRenewalTimeSeconds:                certificateRenewalTimeSeconds,
+		certificateReadyStatus:                       certificateReadyStatus,
+		acmeClientRequestCount:                       acmeClientRequestCount,
+		acmeClientRequestDurationSeconds:             acmeClientRequestDurationSeconds,
+		venafiClientRequestDurationSeconds:           venafiClientRequestDurationSeconds,
+		controllerSyncCallCount:                      controllerSyncCallCount,
+		controllerSyncErrorCount:                     controllerSyncErrorCount,
+		certificateEmptyIssuerGroupCount:             certificateEmptyIssuerGroupCount,
+		certificateRequestPolicyDecisionCount:        certificateRequestPolicyDecisionCount,
+		certificateUpcomingRenewals:                  certificateUpcomingRenewals,
+		certificateSecretParseErrorCount:             certificateSecretParseErrorCount,
+		controllerWorkqueueLatencySeconds:            controllerWorkqueueLatencySeconds,
+		certificateExternalIssuerCount:               certificateExternalIssuerCount,
+		webhookCertLastReloadTimestampSeconds:        webhookCertLastReloadTimestampSeconds,
+		certificateDistinctIssuersInChain:            certificateDistinctIssuersInChain,
+		vaultIssuanceCount:                           vaultIssuanceCount,
+		acmeDNS01RateLimitedCount:                    acmeDNS01RateLimitedCount,
+		certificateTimeToExpiryBucketCount:           certificateTimeToExpiryBucketCount,
+		controllerNoopReconcileCount:                 controllerNoopReconcileCount,
+		shimAnnotationConflictCount:                  shimAnnotationConflictCount,
+		certificateOrphanedSecretCount:               certificateOrphanedSecretCount,
+		acmeHTTP01SelfCheckResponseCodeCount:         acmeHTTP01SelfCheckResponseCodeCount,
+		webhookRequestCount:                          webhookRequestCount,
+		loggingVerbosityLevel:                        loggingVerbosityLevel,
+		certificateIssuerSelectorMismatchCount:       certificateIssuerSelectorMismatchCount,
+		webhookValidationRulesEvaluated:              webhookValidationRulesEvaluated,
+		certificateKeyCertMismatchCount:              certificateKeyCertMismatchCount,
+		metricsScrapeCount:                           metricsScrapeCount,
+		certificateReconcileErrorCount:               certificateReconcileErrorCount,
+		watchedSecretCount:                           watchedSecretCount,
+		certificateRenewalRescheduleCount:            certificateRenewalRescheduleCount,
+		acmeClientProblemCount:                       acmeClientProblemCount,
+		certificateSecretMultiManagedCount:           certificateSecretMultiManagedCount,
+		certificateInBackoffCount:                    certificateInBackoffCount,
+		certificateRequestRequestorCount:             certificateRequestRequestorCount,
+		certificateIssuedCount:                       certificateIssuedCount,
+		webhookPanicRecoveredCount:                   webhookPanicRecoveredCount,
+		certificateRenewalIdenticalCount:             certificateRenewalIdenticalCount,
+		metricsTLSHandshakeDurationSeconds:           metricsTLSHandshakeDurationSeconds,
+		certificateInvalidDurationConfigCount:        certificateInvalidDurationConfigCount,
+		issuerQuotaExceededCount:                     issuerQuotaExceededCount,
+		certificateCrossNamespaceSecretRefCount:      certificateCrossNamespaceSecretRefCount,
+		conversionRequestObjectBytes:                 conversionRequestObjectBytes,
+		certificateSANTypeCount:                      certificateSANTypeCount,
+		certificateBlockedByNotReadyIssuerCount:      certificateBlockedByNotReadyIssuerCount,
+		webhookSlowRequestCount:                      webhookSlowRequestCount,
+		metricsServerBindErrorCount:                  metricsServerBindErrorCount,
+		certificateWeakKeyCount:                      certificateWeakKeyCount,
+		certificateMissingCACrtCount:                 certificateMissingCACrtCount,
+		distinctIssuerRefCount:                       distinctIssuerRefCount,
+		certificateAgeBucketCount:                    certificateAgeBucketCount,
+		metricsCertificateRequestListSize:            metricsCertificateRequestListSize,
+		certificatePendingCount:                      certificatePendingCount,
+		acmeAuthorizationReusedCount:                 acmeAuthorizationReusedCount,
+		certificateImmutableSecretCount:              certificateImmutableSecretCount,
+		controllerWorkersBusy:                        controllerWorkersBusy,
+		controllerWorkersTotal:                       controllerWorkersTotal,
+		acmeNewOrderDurationSeconds:                  acmeNewOrderDurationSeconds,
+		certificateNeedsInterventionCount:            certificateNeedsInterventionCount,
+		webhookServingCertRotationCount:              webhookServingCertRotationCount,
+		webhookServingCertExpirationTimestampSeconds: webhookServingCertExpirationTimestampSeconds,
+		certificateKeystorePasswordMissingCount:      certificateKeystorePasswordMissingCount,
+		controllerResyncObjectCount:                  controllerResyncObjectCount,
+		acmeChallengeCleanupLagSeconds:               acmeChallengeCleanupLagSeconds,
+		certificateTriggeredRequestCount:             certificateTriggeredRequestCount,
+		webhookSNIMismatchCount:                      webhookSNIMismatchCount,
+		certificateChainExpiringSoonCount:            certificateChainExpiringSoonCount,
+		secretWatchEventCount:                        secretWatchEventCount,
+		certificateSubjectFieldCount:                 certificateSubjectFieldCount,
+		certificateRequestApprovalDurationSeconds:    certificateRequestApprovalDurationSeconds,
+		certificateGatedFeatureBlockedCount:          certificateGatedFeatureBlockedCount,
+		acmeClientRequestsInFlight:                   acmeClientRequestsInFlight,
+		webhookTLSNegotiatedCount:                    webhookTLSNegotiatedCount,
+		certificateManualRenewalCount:                certificateManualRenewalCount,
+		certificateIssuanceDurationSeconds:           certificateIssuanceDurationSeconds,
+		acmeClientRequestDurationSecondsHistogram:    acmeClientRequestDurationSecondsHistogram,
+		certificateIssuerRefDriftCount:               certificateIssuerRefDriftCount,
+		venafiClientRequestCount:                     venafiClientRequestCount,
+		acmeOrderFinalizeDurationSeconds:             acmeOrderFinalizeDurationSeconds,
+		shimMissingCertificateCount:                  shimMissingCertificateCount,
+		metricsRequestTimeoutCount:                   metricsRequestTimeoutCount,
+	}
+
+	if m.opts.zeroValuedSeries {
+		m.initZeroValuedSeries()
 	}
 
 	return m
@@ -208,24 +1393,21 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 
 // NewServer registers Prometheus metrics and returns a new Prometheus metrics HTTP server.
 func (m *Metrics) NewServer(ln net.Listener) *http.Server {
-	m.registry.MustRegister(m.clockTimeSeconds)
-	m.registry.MustRegister(m.clockTimeSecondsGauge)
-	m.registry.MustRegister(m.certificateExpiryTimeSeconds)
-	m.registry.MustRegister(m.certificateRenewalTimeSeconds)
-	m.registry.MustRegister(m.certificateReadyStatus)
-	m.registry.MustRegister(m.acmeClientRequestDurationSeconds)
-	m.registry.MustRegister(m.venafiClientRequestDurationSeconds)
-	m.registry.MustRegister(m.acmeClientRequestCount)
-	m.registry.MustRegister(m.controllerSyncCallCount)
-	m.registry.MustRegister(m.controllerSyncErrorCount)
-
 	mux := http.NewServeMux()
-	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	mux.Handle("/metrics", m.MetricsHandler())
+	if m.opts.adminSubjectAccessReviews != nil {
+		if m.opts.tokenReviewAuthentication != nil {
+			mux.Handle(adminMetricsPath, m.authenticate(m.authorizeAdmin(m.adminHandler())))
+		} else {
+			m.log.Info("not serving the metrics admin endpoints, as metrics authentication is not enabled")
+		}
+	}
 
 	server := &http.Server{
 		Addr:           ln.Addr().String(),
 		ReadTimeout:    prometheusMetricsServerReadTimeout,
 		WriteTimeout:   prometheusMetricsServerWriteTimeout,
+		IdleTimeout:    m.opts.idleTimeout,
 		MaxHeaderBytes: prometheusMetricsServerMaxHeaderBytes,
 		Handler:        mux,
 	}
@@ -233,6 +1415,148 @@ func (m *Metrics) NewServer(ln net.Listener) *http.Server {
 	return server
 }
 
+// NewServerE listens on the given TCP address and returns a new Prometheus
+// metrics HTTP server along with the listener it should serve on. If the
+// address cannot be bound, the metrics_server_bind_error_count metric is
+// incremented and the error is returned.
+func (m *Metrics) NewServerE(address string) (*http.Server, net.Listener, error) {
+	ln, err := net.Listen("tcp", address)
+	if err != nil {
+		m.metricsServerBindErrorCount.Inc()
+		return nil, nil, fmt.Errorf("failed to listen on metrics address %s: %w", address, err)
+	}
+
+	return m.NewServer(ln), ln, nil
+}
+
+// Handler registers Prometheus metrics and returns an HTTP handler which
+// serves them, for use when metrics are served alongside other endpoints.
+// Metrics are only registered the first time Handler or NewServer is called.
+func (m *Metrics) Handler() http.Handler {
+	m.registerOnce.Do(m.register)
+
+	var gatherer prometheus.Gatherer = m.registry
+	if m.opts.utf8MetricNames {
+		gatherer = utf8NameGatherer{m.registry}
+	}
+	return promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
+}
+
+// MetricsHandler returns the handler which NewServer serves at /metrics,
+// for use when metrics are served alongside other endpoints. Unlike Handler,
+// requests are authenticated and counted, and are cut off by the same write
+// timeout as the metrics server.
+func (m *Metrics) MetricsHandler() http.Handler {
+	return m.countTimeouts(m.authenticate(m.countScrapes(m.Handler())), prometheusMetricsServerWriteTimeout)
+}
+
+// register registers all Prometheus metrics with the Metrics registry.
+func (m *Metrics) register() {
+	m.collectors = map[string]prometheus.Collector{
+		m.opts.namespace + "_clock_time_seconds":                                  m.clockTimeSeconds,
+		m.opts.namespace + "_clock_time_seconds_gauge":                            m.clockTimeSecondsGauge,
+		m.opts.namespace + "_certificate_expiration_timestamp_seconds":            m.certificateExpiryTimeSeconds,
+		m.opts.namespace + "_certificate_renewal_timestamp_seconds":               m.certificateRenewalTimeSeconds,
+		m.opts.namespace + "_certificate_ready_status":                            m.certificateReadyStatus,
+		m.opts.namespace + "_http_acme_client_request_duration_seconds":           m.acmeClientRequestDurationSeconds,
+		m.opts.namespace + "_http_venafi_client_request_duration_seconds":         m.venafiClientRequestDurationSeconds,
+		m.opts.namespace + "_http_acme_client_request_count":                      m.acmeClientRequestCount,
+		m.opts.namespace + "_controller_sync_call_count":                          m.controllerSyncCallCount,
+		m.opts.namespace + "_controller_sync_error_count":                         m.controllerSyncErrorCount,
+		m.opts.namespace + "_certificate_empty_issuer_group_count":                m.certificateEmptyIssuerGroupCount,
+		m.opts.namespace + "_certificaterequest_policy_decision_count":            m.certificateRequestPolicyDecisionCount,
+		m.opts.namespace + "_certificate_upcoming_renewals":                       m.certificateUpcomingRenewals,
+		m.opts.namespace + "_certificate_secret_parse_error_count":                m.certificateSecretParseErrorCount,
+		m.opts.namespace + "_controller_workqueue_latency_seconds":                m.controllerWorkqueueLatencySeconds,
+		m.opts.namespace + "_certificate_external_issuer_count":                   m.certificateExternalIssuerCount,
+		m.opts.namespace + "_webhook_cert_last_reload_timestamp_seconds":          m.webhookCertLastReloadTimestampSeconds,
+		m.opts.namespace + "_certificate_distinct_issuers_in_chain":               m.certificateDistinctIssuersInChain,
+		m.opts.namespace + "_vault_issuance_count":                                m.vaultIssuanceCount,
+		m.opts.namespace + "_acme_dns01_rate_limited_count":                       m.acmeDNS01RateLimitedCount,
+		m.opts.namespace + "_certificate_time_to_expiry_bucket_count":             m.certificateTimeToExpiryBucketCount,
+		m.opts.namespace + "_controller_noop_reconcile_count":                     m.controllerNoopReconcileCount,
+		m.opts.namespace + "_shim_annotation_conflict_count":                      m.shimAnnotationConflictCount,
+		m.opts.namespace + "_certificate_orphaned_secret_count":                   m.certificateOrphanedSecretCount,
+		m.opts.namespace + "_acme_http01_selfcheck_response_code_count":           m.acmeHTTP01SelfCheckResponseCodeCount,
+		m.opts.namespace + "_webhook_request_count":                               m.webhookRequestCount,
+		m.opts.namespace + "_logging_verbosity_level":                             m.loggingVerbosityLevel,
+		m.opts.namespace + "_certificate_issuer_selector_mismatch_count":          m.certificateIssuerSelectorMismatchCount,
+		m.opts.namespace + "_webhook_validation_rules_evaluated":                  m.webhookValidationRulesEvaluated,
+		m.opts.namespace + "_certificate_key_cert_mismatch_count":                 m.certificateKeyCertMismatchCount,
+		m.opts.namespace + "_metrics_scrape_count":                                m.metricsScrapeCount,
+		m.opts.namespace + "_certificate_reconcile_error_count":                   m.certificateReconcileErrorCount,
+		m.opts.namespace + "_watched_secret_count":                                m.watchedSecretCount,
+		m.opts.namespace + "_certificate_renewal_reschedule_count":                m.certificateRenewalRescheduleCount,
+		m.opts.namespace + "_acme_client_problem_count":                           m.acmeClientProblemCount,
+		m.opts.namespace + "_certificate_secret_multimanaged_count":               m.certificateSecretMultiManagedCount,
+		m.opts.namespace + "_certificate_in_backoff_count":                        m.certificateInBackoffCount,
+		m.opts.namespace + "_certificaterequest_requestor_count":                  m.certificateRequestRequestorCount,
+		m.opts.namespace + "_certificate_issued_count":                            m.certificateIssuedCount,
+		m.opts.namespace + "_webhook_panic_recovered_count":                       m.webhookPanicRecoveredCount,
+		m.opts.namespace + "_certificate_renewal_identical_count":                 m.certificateRenewalIdenticalCount,
+		m.opts.namespace + "_metrics_tls_handshake_duration_seconds":              m.metricsTLSHandshakeDurationSeconds,
+		m.opts.namespace + "_certificate_invalid_duration_config_count":           m.certificateInvalidDurationConfigCount,
+		m.opts.namespace + "_issuer_quota_exceeded_count":                         m.issuerQuotaExceededCount,
+		m.opts.namespace + "_certificate_cross_namespace_secret_ref_count":        m.certificateCrossNamespaceSecretRefCount,
+		m.opts.namespace + "_conversion_request_object_bytes":                     m.conversionRequestObjectBytes,
+		m.opts.namespace + "_certificate_san_type_count":                          m.certificateSANTypeCount,
+		m.opts.namespace + "_certificate_blocked_by_notready_issuer_count":        m.certificateBlockedByNotReadyIssuerCount,
+		m.opts.namespace + "_webhook_slow_request_count":                          m.webhookSlowRequestCount,
+		m.opts.namespace + "_metrics_server_bind_error_count":                     m.metricsServerBindErrorCount,
+		m.opts.namespace + "_certificate_weak_key_count":                          m.certificateWeakKeyCount,
+		m.opts.namespace + "_certificate_missing_ca_crt_count":                    m.certificateMissingCACrtCount,
+		m.opts.namespace + "_distinct_issuerref_count":                            m.distinctIssuerRefCount,
+		m.opts.namespace + "_certificate_age_bucket_count":                        m.certificateAgeBucketCount,
+		m.opts.namespace + "_metrics_certificaterequest_list_size":                m.metricsCertificateRequestListSize,
+		m.opts.namespace + "_certificate_pending_count":                           m.certificatePendingCount,
+		m.opts.namespace + "_acme_authorization_reused_count":                     m.acmeAuthorizationReusedCount,
+		m.opts.namespace + "_certificate_immutable_secret_count":                  m.certificateImmutableSecretCount,
+		m.opts.namespace + "_controller_workers_busy":                             m.controllerWorkersBusy,
+		m.opts.namespace + "_controller_workers_total":                            m.controllerWorkersTotal,
+		m.opts.namespace + "_acme_new_order_duration_seconds":                     m.acmeNewOrderDurationSeconds,
+		m.opts.namespace + "_certificate_needs_intervention_count":                m.certificateNeedsInterventionCount,
+		m.opts.namespace + "_webhook_serving_cert_rotation_count":                 m.webhookServingCertRotationCount,
+		m.opts.namespace + "_webhook_serving_cert_expiration_timestamp_seconds":   m.webhookServingCertExpirationTimestampSeconds,
+		m.opts.namespace + "_certificate_keystore_password_missing_count":         m.certificateKeystorePasswordMissingCount,
+		m.opts.namespace + "_controller_resync_object_count":                      m.controllerResyncObjectCount,
+		m.opts.namespace + "_acme_challenge_cleanup_lag_seconds":                  m.acmeChallengeCleanupLagSeconds,
+		m.opts.namespace + "_certificate_triggered_request_count":                 m.certificateTriggeredRequestCount,
+		m.opts.namespace + "_webhook_sni_mismatch_count":                          m.webhookSNIMismatchCount,
+		m.opts.namespace + "_certificate_chain_expiring_soon_count":               m.certificateChainExpiringSoonCount,
+		m.opts.namespace + "_secret_watch_event_count":                            m.secretWatchEventCount,
+		m.opts.namespace + "_certificate_subject_field_count":                     m.certificateSubjectFieldCount,
+		m.opts.namespace + "_certificaterequest_approval_duration_seconds":        m.certificateRequestApprovalDurationSeconds,
+		m.opts.namespace + "_certificate_gated_feature_blocked_count":             m.certificateGatedFeatureBlockedCount,
+		m.opts.namespace + "_acme_client_requests_in_flight":                      m.acmeClientRequestsInFlight,
+		m.opts.namespace + "_webhook_tls_negotiated_count":                        m.webhookTLSNegotiatedCount,
+		m.opts.namespace + "_certificate_manual_renewal_count":                    m.certificateManualRenewalCount,
+		m.opts.namespace + "_certificate_issuance_duration_seconds":               m.certificateIssuanceDurationSeconds,
+		m.opts.namespace + "_http_acme_client_request_duration_seconds_histogram": m.acmeClientRequestDurationSecondsHistogram,
+		m.opts.namespace + "_certificate_issuerref_drift_count":                   m.certificateIssuerRefDriftCount,
+		m.opts.namespace + "_http_venafi_client_request_count":                    m.venafiClientRequestCount,
+		m.opts.namespace + "_acme_order_finalize_duration_seconds":                m.acmeOrderFinalizeDurationSeconds,
+		m.opts.namespace + "_shim_missing_certificate_count":                      m.shimMissingCertificateCount,
+		m.opts.namespace + "_metrics_request_timeout_count":                       m.metricsRequestTimeoutCount,
+	}
+	if m.webhook {
+		collectors := make(map[string]prometheus.Collector, len(webhookOnlyMetricNames)+len(commonMetricNames))
+		for _, names := range [][]string{webhookOnlyMetricNames, commonMetricNames} {
+			for _, name := range names {
+				name = m.opts.namespace + "_" + name
+				collectors[name] = m.collectors[name]
+			}
+		}
+		m.collectors = collectors
+	} else {
+		for _, name := range webhookOnlyMetricNames {
+			delete(m.collectors, m.opts.namespace+"_"+name)
+		}
+	}
+	for _, c := range m.collectors {
+		m.registry.MustRegister(c)
+	}
+}
+
 // IncrementSyncCallCount will increase the sync counter for that controller.
 func (m *Metrics) IncrementSyncCallCount(controllerName string) {
 	m.controllerSyncCallCount.WithLabelValues(controllerName).Inc()
@@ -242,3 +1566,46 @@ func (m *Metrics) IncrementSyncCallCount(controllerName string) {
 func (m *Metrics) IncrementSyncErrorCount(controllerName string) {
 	m.controllerSyncErrorCount.WithLabelValues(controllerName).Inc()
 }
+
+// IncrementNoopReconcileCount will increase the count of reconciles of that
+// controller which determined that nothing needed to be done.
+func (m *Metrics) IncrementNoopReconcileCount(controllerName string) {
+	m.controllerNoopReconcileCount.WithLabelValues(controllerName).Inc()
+}
+
+// SetControllerWorkersTotal records the number of workers started by that
+// controller.
+func (m *Metrics) SetControllerWorkersTotal(controllerName string, workers int) {
+	m.controllerWorkersTotal.WithLabelValues(controllerName).Set(float64(workers))
+}
+
+// IncrementControllerWorkersBusy will increase the number of that
+// controller's workers which are processing an item. It must be paired with
+// a call to DecrementControllerWorkersBusy once the item has been processed.
+func (m *Metrics) IncrementControllerWorkersBusy(controllerName string) {
+	m.controllerWorkersBusy.WithLabelValues(controllerName).Inc()
+}
+
+// DecrementControllerWorkersBusy will decrease the number of that
+// controller's workers which are processing an item.
+func (m *Metrics) DecrementControllerWorkersBusy(controllerName string) {
+	m.controllerWorkersBusy.WithLabelValues(controllerName).Dec()
+}
+
+// IncrementSecretWatchEvent will increase the count of events of the given
+// type, one of add, update or delete, processed by the Secret informer.
+func (m *Metrics) IncrementSecretWatchEvent(eventType string) {
+	m.secretWatchEventCount.WithLabelValues(eventType).Inc()
+}
+
+// SetControllerResyncObjectCount records the number of objects processed by
+// the most recent full resync of that controller.
+func (m *Metrics) SetControllerResyncObjectCount(controllerName string, count int) {
+	m.controllerResyncObjectCount.WithLabelValues(controllerName).Set(float64(count))
+}
+
+// SetLoggingVerbosity records the log verbosity level the component was
+// started with.
+func (m *Metrics) SetLoggingVerbosity(level uint32) {
+	m.loggingVerbosityLevel.Set(float64(level))
+}
@@ -18,6 +18,7 @@ package metrics
 
 import (
 	"fmt"
+	"net"
 	"strings"
 	"testing"
 	"time"
@@ -25,6 +26,7 @@ import (
 	logtesting "github.com/go-logr/logr/testing"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 
 	fakeclock "k8s.io/utils/clock/testing"
@@ -68,3 +70,185 @@ certmanager_clock_time_seconds_gauge %f
 		})
 	}
 }
+
+func TestNewServerIdleTimeout(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	tests := map[string]struct {
+		opts     []Option
+		expected time.Duration
+	}{
+		"if no idle timeout is given, the default should be used": {
+			expected: 120 * time.Second,
+		},
+		"if an idle timeout is given, it should be used": {
+			opts:     []Option{WithIdleTimeout(5 * time.Second)},
+			expected: 5 * time.Second,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()), test.opts...)
+			assert.Equal(t, test.expected, m.NewServer(ln).IdleTimeout)
+		})
+	}
+}
+
+func TestNewServerEBindError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+
+	server, serverLn, err := m.NewServerE("127.0.0.1:0")
+	if assert.NoError(t, err) {
+		assert.Equal(t, serverLn.Addr().String(), server.Addr)
+		serverLn.Close()
+	}
+
+	// The address is already in use, so binding to it must fail.
+	_, _, err = m.NewServerE(ln.Addr().String())
+	assert.Error(t, err)
+
+	assert.NoError(t, testutil.CollectAndCompare(m.metricsServerBindErrorCount, strings.NewReader(`
+# HELP certmanager_metrics_server_bind_error_count The number of times the metrics server failed to listen on its configured address.
+# TYPE certmanager_metrics_server_bind_error_count counter
+certmanager_metrics_server_bind_error_count 1
+`), "certmanager_metrics_server_bind_error_count"))
+}
+
+func TestSummaryObjectives(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()),
+		WithSummaryObjectives("certmanager_http_venafi_client_request_duration_seconds", map[float64]float64{0.5: 0.05, 0.999: 0.0001}),
+	)
+	m.ObserveACMERequestDuration(time.Second, "https", "acme.example.com", "/", "GET", "200")
+	m.ObserveVenafiRequestDuration(time.Second, "request")
+
+	tests := map[string]struct {
+		metricName string
+		metric     prometheus.Collector
+
+		expected string
+	}{
+		"summaries without objectives set should use the defaults": {
+			metricName: "certmanager_http_acme_client_request_duration_seconds",
+			metric:     m.acmeClientRequestDurationSeconds,
+			expected: `
+# HELP certmanager_http_acme_client_request_duration_seconds DEPRECATED: use http_acme_client_request_duration_seconds_histogram instead. The HTTP request latencies in seconds for the ACME client.
+# TYPE certmanager_http_acme_client_request_duration_seconds summary
+certmanager_http_acme_client_request_duration_seconds{host="acme.example.com",method="GET",path="/",scheme="https",status="200",quantile="0.5"} 1
+certmanager_http_acme_client_request_duration_seconds{host="acme.example.com",method="GET",path="/",scheme="https",status="200",quantile="0.9"} 1
+certmanager_http_acme_client_request_duration_seconds{host="acme.example.com",method="GET",path="/",scheme="https",status="200",quantile="0.99"} 1
+certmanager_http_acme_client_request_duration_seconds_sum{host="acme.example.com",method="GET",path="/",scheme="https",status="200"} 1
+certmanager_http_acme_client_request_duration_seconds_count{host="acme.example.com",method="GET",path="/",scheme="https",status="200"} 1
+`,
+		},
+		"summaries with objectives set should use them": {
+			metricName: "certmanager_http_venafi_client_request_duration_seconds",
+			metric:     m.venafiClientRequestDurationSeconds,
+			expected: `
+# HELP certmanager_http_venafi_client_request_duration_seconds ALPHA: The HTTP request latencies in seconds for the Venafi client. This metric is currently alpha as we would like to understand whether it helps to measure Venafi call latency. Please leave feedback if you have any.
+# TYPE certmanager_http_venafi_client_request_duration_seconds summary
+certmanager_http_venafi_client_request_duration_seconds{api_call="request",quantile="0.5"} 1
+certmanager_http_venafi_client_request_duration_seconds{api_call="request",quantile="0.999"} 1
+certmanager_http_venafi_client_request_duration_seconds_sum{api_call="request"} 1
+certmanager_http_venafi_client_request_duration_seconds_count{api_call="request"} 1
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.NoError(t,
+				testutil.CollectAndCompare(test.metric, strings.NewReader(test.expected), test.metricName),
+			)
+		})
+	}
+}
+
+func TestACMERequestDurationHistogram(t *testing.T) {
+	const metricName = "certmanager_http_acme_client_request_duration_seconds_histogram"
+
+	t.Run("default buckets", func(t *testing.T) {
+		m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+		m.ObserveACMERequestDuration(time.Second, "https", "acme.example.com", "/", "GET", "200")
+
+		var metric dto.Metric
+		if err := m.acmeClientRequestDurationSecondsHistogram.WithLabelValues("https", "acme.example.com", "/", "GET", "200").(prometheus.Metric).Write(&metric); err != nil {
+			t.Fatal(err)
+		}
+		var upperBounds []float64
+		for _, bucket := range metric.GetHistogram().GetBucket() {
+			upperBounds = append(upperBounds, bucket.GetUpperBound())
+		}
+		assert.Equal(t, prometheus.ExponentialBuckets(0.01, 2, 12), upperBounds)
+	})
+
+	t.Run("configured buckets and labels", func(t *testing.T) {
+		m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()),
+			WithHistogramBuckets(metricName, []float64{0.1, 1}),
+		)
+		m.ObserveACMERequestDuration(50*time.Millisecond, "https", "acme.example.com", "/acme/new-order", "POST", "201")
+		m.ObserveACMERequestDuration(500*time.Millisecond, "https", "acme.example.com", "/acme/new-order", "POST", "201")
+		m.ObserveACMERequestDuration(2*time.Second, "https", "acme.example.com", "/directory", "GET", "200")
+
+		// There is one series for each distinct set of labels.
+		assert.Equal(t, 2, testutil.CollectAndCount(m.acmeClientRequestDurationSecondsHistogram, metricName))
+		assert.NoError(t, testutil.CollectAndCompare(m.acmeClientRequestDurationSecondsHistogram, strings.NewReader(`
+# HELP certmanager_http_acme_client_request_duration_seconds_histogram The HTTP request latencies in seconds for the ACME client.
+# TYPE certmanager_http_acme_client_request_duration_seconds_histogram histogram
+certmanager_http_acme_client_request_duration_seconds_histogram_bucket{host="acme.example.com",method="GET",path="/directory",scheme="https",status="200",le="0.1"} 0
+certmanager_http_acme_client_request_duration_seconds_histogram_bucket{host="acme.example.com",method="GET",path="/directory",scheme="https",status="200",le="1"} 0
+certmanager_http_acme_client_request_duration_seconds_histogram_bucket{host="acme.example.com",method="GET",path="/directory",scheme="https",status="200",le="+Inf"} 1
+certmanager_http_acme_client_request_duration_seconds_histogram_sum{host="acme.example.com",method="GET",path="/directory",scheme="https",status="200"} 2
+certmanager_http_acme_client_request_duration_seconds_histogram_count{host="acme.example.com",method="GET",path="/directory",scheme="https",status="200"} 1
+certmanager_http_acme_client_request_duration_seconds_histogram_bucket{host="acme.example.com",method="POST",path="/acme/new-order",scheme="https",status="201",le="0.1"} 1
+certmanager_http_acme_client_request_duration_seconds_histogram_bucket{host="acme.example.com",method="POST",path="/acme/new-order",scheme="https",status="201",le="1"} 2
+certmanager_http_acme_client_request_duration_seconds_histogram_bucket{host="acme.example.com",method="POST",path="/acme/new-order",scheme="https",status="201",le="+Inf"} 2
+certmanager_http_acme_client_request_duration_seconds_histogram_sum{host="acme.example.com",method="POST",path="/acme/new-order",scheme="https",status="201"} 0.55
+certmanager_http_acme_client_request_duration_seconds_histogram_count{host="acme.example.com",method="POST",path="/acme/new-order",scheme="https",status="201"} 2
+`), metricName))
+
+		// The deprecated summary is still observed.
+		assert.Equal(t, 2, testutil.CollectAndCount(m.acmeClientRequestDurationSeconds, "certmanager_http_acme_client_request_duration_seconds"))
+	})
+}
+
+func TestNamespace(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()), WithNamespace("myorg"))
+	m.Handler()
+
+	for _, name := range []string{
+		"myorg_certificate_ready_status",
+		"myorg_controller_sync_call_count",
+		"myorg_http_acme_client_request_count",
+		"myorg_acme_order_finalize_duration_seconds",
+		"myorg_http_venafi_client_request_duration_seconds",
+	} {
+		assert.Contains(t, m.collectors, name)
+	}
+
+	// Every collector is registered under, and describes metrics with, a
+	// name in the configured namespace.
+	for name, c := range m.collectors {
+		assert.True(t, strings.HasPrefix(name, "myorg_"), "collector %q is not in the configured namespace", name)
+
+		descs := make(chan *prometheus.Desc, 16)
+		c.Describe(descs)
+		close(descs)
+		for desc := range descs {
+			assert.Contains(t, desc.String(), fmt.Sprintf("fqName: %q", name))
+		}
+	}
+
+	m.ObserveACMERequestDuration(time.Second, "https", "acme.example.com", "/directory", "GET", "200")
+	assert.Equal(t, 1, testutil.CollectAndCount(m.acmeClientRequestDurationSecondsHistogram, "myorg_http_acme_client_request_duration_seconds_histogram"))
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// updateCertificateOrphanedSecretCount counts the Secrets labelled as managed
+// by cert-manager which are neither named in the spec.secretName of an
+// existing Certificate, nor controlled by one. These are typically left
+// behind when a Certificate is deleted without owner references enabled.
+func (m *Metrics) updateCertificateOrphanedSecretCount(crts []*cmapi.Certificate, managedSecrets []*corev1.Secret) {
+	values := newGaugeValues()
+
+	secretNames := make(map[types.NamespacedName]struct{}, len(crts))
+	crtUIDs := make(map[types.UID]struct{}, len(crts))
+	for _, crt := range crts {
+		secretNames[types.NamespacedName{Namespace: crt.Namespace, Name: crt.Spec.SecretName}] = struct{}{}
+		crtUIDs[crt.UID] = struct{}{}
+	}
+
+	for _, secret := range managedSecrets {
+		if _, ok := secretNames[types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}]; ok {
+			continue
+		}
+		if owner := metav1.GetControllerOf(secret); owner != nil && owner.Kind == cmapi.CertificateKind {
+			if _, ok := crtUIDs[owner.UID]; ok {
+				continue
+			}
+		}
+
+		values.inc(secret.Namespace)
+	}
+
+	m.setGaugeValues(m.certificateOrphanedSecretCount, values)
+}
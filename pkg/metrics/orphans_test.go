@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+const orphanedSecretMetadata = `
+	# HELP certmanager_certificate_orphaned_secret_count The number of Secrets managed by cert-manager which are not referenced or owned by any Certificate.
+	# TYPE certmanager_certificate_orphaned_secret_count gauge
+`
+
+func TestResyncCertificateOrphanedSecretCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crt := gen.Certificate("crt1",
+		gen.SetCertificateNamespace("ns1"),
+		gen.SetCertificateSecretName("crt1-tls"),
+		gen.SetCertificateUID("crt1-uid"),
+	)
+	nextPrivateKey := testSecret("crt1-abcde", "ns1", nil)
+	nextPrivateKey.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(crt, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind))}
+
+	deletedCrt := gen.Certificate("deleted", gen.SetCertificateNamespace("ns1"), gen.SetCertificateUID("deleted-uid"))
+	orphanedNextPrivateKey := testSecret("deleted-abcde", "ns1", nil)
+	orphanedNextPrivateKey.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(deletedCrt, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind))}
+
+	m.Resync(ResyncState{
+		Certificates: []*cmapi.Certificate{crt},
+		ManagedSecrets: []*corev1.Secret{
+			testSecret("crt1-tls", "ns1", nil),
+			nextPrivateKey,
+			orphanedNextPrivateKey,
+			testSecret("deleted-tls", "ns1", nil),
+			// A Secret with the same name in another namespace is not
+			// referenced by the Certificate.
+			testSecret("crt1-tls", "ns2", nil),
+		},
+	})
+	if err := testutil.CollectAndCompare(m.certificateOrphanedSecretCount,
+		strings.NewReader(orphanedSecretMetadata+`
+	certmanager_certificate_orphaned_secret_count{namespace="ns1"} 2
+	certmanager_certificate_orphaned_secret_count{namespace="ns2"} 1
+`),
+		"certmanager_certificate_orphaned_secret_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// ResyncState is a point-in-time view of the resources used to recompute the
+// aggregate metrics on each resync.
+type ResyncState struct {
+	// Certificates is the list of all Certificates known to the controller.
+	Certificates []*cmapi.Certificate
+
+	// CertificateRequests is the list of all CertificateRequests known to the
+	// controller.
+	CertificateRequests []*cmapi.CertificateRequest
+
+	// Secrets is the list of Secrets referenced by the Certificates. Secrets
+	// which are not referenced by any Certificate are ignored.
+	Secrets []*corev1.Secret
+
+	// KeystorePasswordSecrets is the list of Secrets referenced as the
+	// password of a JKS or PKCS#12 keystore by the Certificates.
+	KeystorePasswordSecrets []*corev1.Secret
+
+	// ManagedSecrets is the list of all Secrets labelled as managed by
+	// cert-manager, whether or not they are referenced by a Certificate.
+	ManagedSecrets []*corev1.Secret
+
+	// Issuers is the list of all Issuers known to the controller.
+	Issuers []*cmapi.Issuer
+
+	// ClusterIssuers is the list of all ClusterIssuers known to the
+	// controller.
+	ClusterIssuers []*cmapi.ClusterIssuer
+
+	// WatchedSecrets is the number of Secrets held in the controller's Secret
+	// informer cache.
+	WatchedSecrets int
+
+	// ClusterResourceNamespace is the namespace in which the Secrets
+	// referenced by ClusterIssuers are stored.
+	ClusterResourceNamespace string
+}
+
+// Resync recomputes the aggregate metrics from the given state. Series for
+// objects which no longer exist are deleted, but aggregate metrics are never
+// reset, so scrapes made during a resync observe either the previous or the
+// recomputed value of each series. Aggregate metrics which depend on the
+// behaviour of the controllers, such as issuance backoff, feature gates and
+// ingress-shim, are computed by the caller and set with the Set methods.
+func (m *Metrics) Resync(state ResyncState) {
+	m.updateCertificateEmptyIssuerGroupCount(state.Certificates)
+	m.updateCertificateUpcomingRenewals(state.Certificates)
+	m.updateCertificateExternalIssuerCount(state.Certificates)
+	m.updateCertificateTimeToExpiry(state.Certificates)
+	m.updateCertificateAge(state.Certificates)
+	m.updateCertificatePendingCount(state.Certificates)
+	m.updateCertificateInvalidDurationConfigCount(state.Certificates)
+	m.updateCertificateSANTypeCount(state.Certificates)
+	m.updateCertificateSubjectFieldCount(state.Certificates)
+	m.updateDistinctIssuerRefCount(state.Certificates)
+	m.updateCertificateRequestRequestorCount(state.CertificateRequests)
+	m.updateCertificateNeedsInterventionCount(state.Certificates, state.CertificateRequests)
+	m.updateCertificateOrphanedSecretCount(state.Certificates, state.ManagedSecrets)
+	m.updateCertificateKeystorePasswordMissingCount(state.Certificates, state.KeystorePasswordSecrets)
+	m.updateCertificateIssuerSelectorMismatchCount(state.Certificates, state.Issuers, state.ClusterIssuers)
+	m.updateCertificateBlockedByNotReadyIssuerCount(state.Certificates, state.Issuers, state.ClusterIssuers)
+	m.updateCertificateCrossNamespaceSecretRefCount(state.Certificates, state.ClusterIssuers, state.ClusterResourceNamespace)
+	m.watchedSecretCount.Set(float64(state.WatchedSecrets))
+	m.metricsCertificateRequestListSize.Set(float64(len(state.CertificateRequests)))
+
+	// Decoding the Secrets counts those which fail to decode.
+	crtSecrets := m.certificateSecrets(state.Certificates, state.Secrets)
+	m.updateCertificateDistinctIssuersInChain(crtSecrets)
+	m.updateCertificateKeyCertMismatchCount(crtSecrets)
+	m.updateCertificateSecretMultiManagedCount(crtSecrets)
+	m.updateCertificateWeakKeyCount(crtSecrets)
+	m.updateCertificateMissingCACrtCount(crtSecrets)
+	m.updateCertificateImmutableSecretCount(crtSecrets)
+	m.updateCertificateChainExpiringSoonCount(crtSecrets)
+	m.updateCertificateIssuerRefDriftCount(crtSecrets)
+}
@@ -0,0 +1,589 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+const emptyIssuerGroupMetadata = `
+	# HELP certmanager_certificate_empty_issuer_group_count The number of Certificates with an empty issuerRef group.
+	# TYPE certmanager_certificate_empty_issuer_group_count gauge
+`
+
+const upcomingRenewalsMetadata = `
+	# HELP certmanager_certificate_upcoming_renewals The number of Certificates due to be renewed within the given window, including those overdue for renewal. The overdue window only counts those overdue for renewal.
+	# TYPE certmanager_certificate_upcoming_renewals gauge
+`
+
+const externalIssuerMetadata = `
+	# HELP certmanager_certificate_external_issuer_count The number of Certificates which reference an external issuer, i.e. one outside of the cert-manager.io group.
+	# TYPE certmanager_certificate_external_issuer_count gauge
+`
+
+const timeToExpiryMetadata = `
+	# HELP certmanager_certificate_time_to_expiry_bucket_count The number of Certificates which expire within le seconds, as of the last resync. Expired Certificates are counted in every bucket. This is a gauge, not a histogram.
+	# TYPE certmanager_certificate_time_to_expiry_bucket_count gauge
+`
+
+func TestResyncCertificateEmptyIssuerGroupCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crtWithGroup := func(name, namespace, group string) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace(namespace),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{
+				Name:  "test-issuer",
+				Kind:  "Issuer",
+				Group: group,
+			}),
+		)
+	}
+
+	m.Resync(ResyncState{Certificates: []*cmapi.Certificate{
+		crtWithGroup("crt1", "ns1", ""),
+		crtWithGroup("crt2", "ns1", ""),
+		crtWithGroup("crt3", "ns1", "cert-manager.io"),
+		crtWithGroup("crt4", "ns2", ""),
+	}})
+	if err := testutil.CollectAndCompare(m.certificateEmptyIssuerGroupCount,
+		strings.NewReader(emptyIssuerGroupMetadata+`
+	certmanager_certificate_empty_issuer_group_count{namespace="ns1"} 2
+	certmanager_certificate_empty_issuer_group_count{namespace="ns2"} 1
+`),
+		"certmanager_certificate_empty_issuer_group_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Counts from the previous resync should not be carried over.
+	m.Resync(ResyncState{Certificates: []*cmapi.Certificate{
+		crtWithGroup("crt1", "ns1", "cert-manager.io"),
+		crtWithGroup("crt4", "ns2", ""),
+	}})
+	if err := testutil.CollectAndCompare(m.certificateEmptyIssuerGroupCount,
+		strings.NewReader(emptyIssuerGroupMetadata+`
+	certmanager_certificate_empty_issuer_group_count{namespace="ns2"} 1
+`),
+		"certmanager_certificate_empty_issuer_group_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestResyncCertificateUpcomingRenewals(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(now))
+
+	crtRenewingIn := func(name string, d time.Duration) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateRenewalTime(metav1.NewTime(now.Add(d))),
+		)
+	}
+
+	m.Resync(ResyncState{Certificates: []*cmapi.Certificate{
+		crtRenewingIn("crt1", 30*time.Minute),
+		crtRenewingIn("crt2", 2*time.Hour),
+		crtRenewingIn("crt3", 48*time.Hour),
+		crtRenewingIn("crt4", 30*24*time.Hour),
+		// Certificates overdue for renewal are counted in every window.
+		crtRenewingIn("crt5", -time.Hour),
+		// Certificates without a renewal time are ignored.
+		gen.Certificate("crt6", gen.SetCertificateNamespace("test-ns")),
+	}})
+	if err := testutil.CollectAndCompare(m.certificateUpcomingRenewals,
+		strings.NewReader(upcomingRenewalsMetadata+`
+	certmanager_certificate_upcoming_renewals{window="1h"} 2
+	certmanager_certificate_upcoming_renewals{window="24h"} 3
+	certmanager_certificate_upcoming_renewals{window="7d"} 4
+	certmanager_certificate_upcoming_renewals{window="overdue"} 1
+`),
+		"certmanager_certificate_upcoming_renewals",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestResyncCertificateExternalIssuerCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crtWithIssuer := func(name, kind, group string) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{
+				Name:  "test-issuer",
+				Kind:  kind,
+				Group: group,
+			}),
+		)
+	}
+
+	m.Resync(ResyncState{Certificates: []*cmapi.Certificate{
+		crtWithIssuer("crt1", "Issuer", ""),
+		crtWithIssuer("crt2", "ClusterIssuer", "cert-manager.io"),
+		crtWithIssuer("crt3", "AWSPCAIssuer", "awspca.cert-manager.io"),
+		crtWithIssuer("crt4", "AWSPCAIssuer", "awspca.cert-manager.io"),
+		crtWithIssuer("crt5", "OriginIssuer", "cert-manager.k8s.cloudflare.com"),
+	}})
+	if err := testutil.CollectAndCompare(m.certificateExternalIssuerCount,
+		strings.NewReader(externalIssuerMetadata+`
+	certmanager_certificate_external_issuer_count{issuer_group="awspca.cert-manager.io",issuer_kind="AWSPCAIssuer"} 2
+	certmanager_certificate_external_issuer_count{issuer_group="cert-manager.k8s.cloudflare.com",issuer_kind="OriginIssuer"} 1
+`),
+		"certmanager_certificate_external_issuer_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestResyncCertificateTimeToExpiry(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(now))
+
+	crtExpiringIn := func(name, kind string, d time.Duration) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: kind}),
+			gen.SetCertificateNotAfter(metav1.NewTime(now.Add(d))),
+		)
+	}
+
+	m.Resync(ResyncState{Certificates: []*cmapi.Certificate{
+		crtExpiringIn("crt1", "Issuer", 30*time.Minute),
+		crtExpiringIn("crt2", "Issuer", 10*24*time.Hour),
+		crtExpiringIn("crt3", "Issuer", 100*24*time.Hour),
+		// Expired Certificates are counted in every bucket.
+		crtExpiringIn("crt4", "ClusterIssuer", -time.Hour),
+		// Certificates which have not been issued are ignored.
+		gen.Certificate("crt5", gen.SetCertificateNamespace("test-ns")),
+	}})
+	if err := testutil.CollectAndCompare(m.certificateTimeToExpiryBucketCount,
+		strings.NewReader(timeToExpiryMetadata+`
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="ClusterIssuer",le="3600"} 1
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="ClusterIssuer",le="21600"} 1
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="ClusterIssuer",le="86400"} 1
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="ClusterIssuer",le="604800"} 1
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="ClusterIssuer",le="1.2096e+06"} 1
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="ClusterIssuer",le="2.592e+06"} 1
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="ClusterIssuer",le="5.184e+06"} 1
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="ClusterIssuer",le="7.776e+06"} 1
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="ClusterIssuer",le="1.5552e+07"} 1
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="ClusterIssuer",le="3.1536e+07"} 1
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="ClusterIssuer",le="+Inf"} 1
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="Issuer",le="3600"} 1
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="Issuer",le="21600"} 1
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="Issuer",le="86400"} 1
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="Issuer",le="604800"} 1
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="Issuer",le="1.2096e+06"} 2
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="Issuer",le="2.592e+06"} 2
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="Issuer",le="5.184e+06"} 2
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="Issuer",le="7.776e+06"} 2
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="Issuer",le="1.5552e+07"} 3
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="Issuer",le="3.1536e+07"} 3
+	certmanager_certificate_time_to_expiry_bucket_count{issuer_kind="Issuer",le="+Inf"} 3
+`),
+		"certmanager_certificate_time_to_expiry_bucket_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const certificateAgeMetadata = `
+	# HELP certmanager_certificate_age_bucket_count The number of Ready Certificates which became valid within the last le seconds, as of the last resync. This is a gauge, not a histogram.
+	# TYPE certmanager_certificate_age_bucket_count gauge
+`
+
+func TestResyncCertificateAge(t *testing.T) {
+	now := time.Unix(10000000, 0)
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(now))
+
+	crtIssuedAgo := func(name string, d time.Duration, ready cmmeta.ConditionStatus) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: "Issuer"}),
+			gen.SetCertificateNotBefore(metav1.NewTime(now.Add(-d))),
+			gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: ready}),
+		)
+	}
+
+	m.Resync(ResyncState{Certificates: []*cmapi.Certificate{
+		crtIssuedAgo("crt1", 2*time.Hour, cmmeta.ConditionTrue),
+		crtIssuedAgo("crt2", 40*24*time.Hour, cmmeta.ConditionTrue),
+		// Certificates which are not Ready are ignored.
+		crtIssuedAgo("crt3", time.Hour, cmmeta.ConditionFalse),
+		// Certificates which have not been issued are ignored.
+		gen.Certificate("crt4",
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
+		),
+	}})
+	if err := testutil.CollectAndCompare(m.certificateAgeBucketCount,
+		strings.NewReader(certificateAgeMetadata+`
+	certmanager_certificate_age_bucket_count{issuer_kind="Issuer",le="3600"} 0
+	certmanager_certificate_age_bucket_count{issuer_kind="Issuer",le="21600"} 1
+	certmanager_certificate_age_bucket_count{issuer_kind="Issuer",le="86400"} 1
+	certmanager_certificate_age_bucket_count{issuer_kind="Issuer",le="604800"} 1
+	certmanager_certificate_age_bucket_count{issuer_kind="Issuer",le="1.2096e+06"} 1
+	certmanager_certificate_age_bucket_count{issuer_kind="Issuer",le="2.592e+06"} 1
+	certmanager_certificate_age_bucket_count{issuer_kind="Issuer",le="5.184e+06"} 2
+	certmanager_certificate_age_bucket_count{issuer_kind="Issuer",le="7.776e+06"} 2
+	certmanager_certificate_age_bucket_count{issuer_kind="Issuer",le="1.5552e+07"} 2
+	certmanager_certificate_age_bucket_count{issuer_kind="Issuer",le="3.1536e+07"} 2
+	certmanager_certificate_age_bucket_count{issuer_kind="Issuer",le="+Inf"} 2
+`),
+		"certmanager_certificate_age_bucket_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Observations from the previous resync should not be carried over.
+	m.Resync(ResyncState{})
+	if err := testutil.CollectAndCompare(m.certificateAgeBucketCount,
+		strings.NewReader(""),
+		"certmanager_certificate_age_bucket_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const pendingMetadata = `
+	# HELP certmanager_certificate_pending_count The number of Certificates pending issuance, by phase (initial or renewal) and issuer kind.
+	# TYPE certmanager_certificate_pending_count gauge
+`
+
+func TestResyncCertificatePendingCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	issuing := gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue})
+	crt := func(name, kind string, mods ...gen.CertificateModifier) *cmapi.Certificate {
+		mods = append(mods, gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: kind}))
+		return gen.Certificate(name, mods...)
+	}
+
+	m.Resync(ResyncState{Certificates: []*cmapi.Certificate{
+		// Certificates which have never been issued are pending initial
+		// issuance, even if they are not currently being issued.
+		crt("crt1", "Issuer", issuing),
+		crt("crt2", "Issuer"),
+		crt("crt3", "ClusterIssuer", issuing, gen.SetCertificateRevision(1)),
+		// Issued Certificates which are not being reissued are not pending.
+		crt("crt4", "Issuer", gen.SetCertificateRevision(1)),
+	}})
+	if err := testutil.CollectAndCompare(m.certificatePendingCount,
+		strings.NewReader(pendingMetadata+`
+	certmanager_certificate_pending_count{issuer_kind="ClusterIssuer",phase="renewal"} 1
+	certmanager_certificate_pending_count{issuer_kind="Issuer",phase="initial"} 2
+`),
+		"certmanager_certificate_pending_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	m.Resync(ResyncState{})
+	if err := testutil.CollectAndCompare(m.certificatePendingCount,
+		strings.NewReader(""),
+		"certmanager_certificate_pending_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestResyncCertificateRequestListSize(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	m.Resync(ResyncState{CertificateRequests: []*cmapi.CertificateRequest{
+		gen.CertificateRequest("cr1"),
+		gen.CertificateRequest("cr2"),
+	}})
+	if err := testutil.CollectAndCompare(m.metricsCertificateRequestListSize,
+		strings.NewReader(`
+	# HELP certmanager_metrics_certificaterequest_list_size The number of CertificateRequests listed to compute the aggregate metrics on the last resync.
+	# TYPE certmanager_metrics_certificaterequest_list_size gauge
+	certmanager_metrics_certificaterequest_list_size 2
+`),
+		"certmanager_metrics_certificaterequest_list_size",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestResyncWatchedSecretCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	m.Resync(ResyncState{WatchedSecrets: 42})
+	if err := testutil.CollectAndCompare(m.watchedSecretCount,
+		strings.NewReader(`
+	# HELP certmanager_watched_secret_count The number of Secrets held in the controller's Secret informer cache.
+	# TYPE certmanager_watched_secret_count gauge
+	certmanager_watched_secret_count 42
+`),
+		"certmanager_watched_secret_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const inBackoffMetadata = `
+	# HELP certmanager_certificate_in_backoff_count The number of Certificates backing off from re-issuance after a failed issuance attempt.
+	# TYPE certmanager_certificate_in_backoff_count gauge
+`
+
+func TestSetCertificatesInBackoff(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crt := func(name, kind string) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: kind, Group: "cert-manager.io"}),
+		)
+	}
+
+	m.SetCertificatesInBackoff([]*cmapi.Certificate{
+		crt("crt1", "Issuer"),
+		crt("crt2", "Issuer"),
+		crt("crt3", "ClusterIssuer"),
+	})
+	if err := testutil.CollectAndCompare(m.certificateInBackoffCount,
+		strings.NewReader(inBackoffMetadata+`
+	certmanager_certificate_in_backoff_count{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer"} 1
+	certmanager_certificate_in_backoff_count{issuer_group="cert-manager.io",issuer_kind="Issuer"} 2
+`),
+		"certmanager_certificate_in_backoff_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const invalidDurationConfigMetadata = `
+	# HELP certmanager_certificate_invalid_duration_config_count The number of Certificates whose renewBefore is greater than or equal to their duration, causing them to be renewed continuously.
+	# TYPE certmanager_certificate_invalid_duration_config_count gauge
+`
+
+func TestResyncCertificateInvalidDurationConfigCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	m.Resync(ResyncState{Certificates: []*cmapi.Certificate{
+		gen.Certificate("crt1",
+			gen.SetCertificateNamespace("ns1"),
+			gen.SetCertificateDuration(time.Hour),
+			gen.SetCertificateRenewBefore(time.Hour),
+		),
+		gen.Certificate("crt2",
+			gen.SetCertificateNamespace("ns1"),
+			gen.SetCertificateDuration(time.Hour),
+			gen.SetCertificateRenewBefore(30*time.Minute),
+		),
+		// Certificates without a duration use the default of 90 days.
+		gen.Certificate("crt3",
+			gen.SetCertificateNamespace("ns2"),
+			gen.SetCertificateRenewBefore(100*24*time.Hour),
+		),
+		gen.Certificate("crt4",
+			gen.SetCertificateNamespace("ns2"),
+			gen.SetCertificateRenewBefore(30*24*time.Hour),
+		),
+		// Certificates without a renewBefore are renewed after two thirds of
+		// their duration.
+		gen.Certificate("crt5", gen.SetCertificateNamespace("ns2")),
+	}})
+	if err := testutil.CollectAndCompare(m.certificateInvalidDurationConfigCount,
+		strings.NewReader(invalidDurationConfigMetadata+`
+	certmanager_certificate_invalid_duration_config_count{namespace="ns1"} 1
+	certmanager_certificate_invalid_duration_config_count{namespace="ns2"} 1
+`),
+		"certmanager_certificate_invalid_duration_config_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Counts from the previous resync should not be carried over.
+	m.Resync(ResyncState{})
+	if err := testutil.CollectAndCompare(m.certificateInvalidDurationConfigCount,
+		strings.NewReader(""),
+		"certmanager_certificate_invalid_duration_config_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const distinctIssuerRefMetadata = `
+	# HELP certmanager_distinct_issuerref_count The number of distinct issuers referenced by Certificates.
+	# TYPE certmanager_distinct_issuerref_count gauge
+`
+
+func TestResyncDistinctIssuerRefCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crt := func(namespace string, ref cmmeta.ObjectReference) *cmapi.Certificate {
+		return gen.Certificate("test", gen.SetCertificateNamespace(namespace), gen.SetCertificateIssuer(ref))
+	}
+	m.Resync(ResyncState{Certificates: []*cmapi.Certificate{
+		// The default kind and group refer to the same Issuer.
+		crt("ns-1", cmmeta.ObjectReference{Name: "issuer"}),
+		crt("ns-1", cmmeta.ObjectReference{Name: "issuer", Kind: "Issuer", Group: "cert-manager.io"}),
+		// Issuers in different namespaces are distinct.
+		crt("ns-2", cmmeta.ObjectReference{Name: "issuer"}),
+		// ClusterIssuers are the same across namespaces.
+		crt("ns-1", cmmeta.ObjectReference{Name: "issuer", Kind: "ClusterIssuer"}),
+		crt("ns-2", cmmeta.ObjectReference{Name: "issuer", Kind: "ClusterIssuer", Group: "cert-manager.io"}),
+		crt("ns-1", cmmeta.ObjectReference{Name: "issuer", Kind: "ExternalIssuer", Group: "example.com"}),
+	}})
+	if err := testutil.CollectAndCompare(m.distinctIssuerRefCount,
+		strings.NewReader(distinctIssuerRefMetadata+`
+	certmanager_distinct_issuerref_count 4
+`),
+		"certmanager_distinct_issuerref_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	m.Resync(ResyncState{})
+	if err := testutil.CollectAndCompare(m.distinctIssuerRefCount,
+		strings.NewReader(distinctIssuerRefMetadata+`
+	certmanager_distinct_issuerref_count 0
+`),
+		"certmanager_distinct_issuerref_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const sanTypeMetadata = `
+	# HELP certmanager_certificate_san_type_count The number of subject alternative names requested across all Certificates, by SAN type.
+	# TYPE certmanager_certificate_san_type_count gauge
+`
+
+func TestResyncCertificateSANTypeCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	m.Resync(ResyncState{Certificates: []*cmapi.Certificate{
+		gen.Certificate("crt1",
+			gen.SetCertificateDNSNames("example.com", "www.example.com"),
+			gen.SetCertificateIPs("10.0.0.1"),
+		),
+		gen.Certificate("crt2",
+			gen.SetCertificateDNSNames("example.org"),
+			gen.SetCertificateURIs("spiffe://cluster.local/ns/foo/sa/bar"),
+		),
+	}})
+	if err := testutil.CollectAndCompare(m.certificateSANTypeCount,
+		strings.NewReader(sanTypeMetadata+`
+	certmanager_certificate_san_type_count{san_type="dns"} 3
+	certmanager_certificate_san_type_count{san_type="email"} 0
+	certmanager_certificate_san_type_count{san_type="ip"} 1
+	certmanager_certificate_san_type_count{san_type="uri"} 1
+`),
+		"certmanager_certificate_san_type_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Counts from the previous resync should not be carried over.
+	m.Resync(ResyncState{Certificates: []*cmapi.Certificate{
+		gen.Certificate("crt3", gen.SetCertificateEmails("admin@example.com")),
+	}})
+	if err := testutil.CollectAndCompare(m.certificateSANTypeCount,
+		strings.NewReader(sanTypeMetadata+`
+	certmanager_certificate_san_type_count{san_type="dns"} 0
+	certmanager_certificate_san_type_count{san_type="email"} 1
+	certmanager_certificate_san_type_count{san_type="ip"} 0
+	certmanager_certificate_san_type_count{san_type="uri"} 0
+`),
+		"certmanager_certificate_san_type_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const subjectFieldMetadata = `
+	# HELP certmanager_certificate_subject_field_count The number of Certificates which set each subject field other than the common name.
+	# TYPE certmanager_certificate_subject_field_count gauge
+`
+
+func TestResyncCertificateSubjectFieldCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	withSubject := gen.Certificate("crt2")
+	withSubject.Spec.Subject = &cmapi.X509Subject{
+		Organizations: []string{"Example Inc."},
+		Countries:     []string{"GB"},
+		SerialNumber:  "1234",
+	}
+	withOrganizations := gen.Certificate("crt3")
+	withOrganizations.Spec.Subject = &cmapi.X509Subject{Organizations: []string{"Example Inc.", "Example Ltd."}}
+	withLiteralSubject := gen.Certificate("crt4")
+	withLiteralSubject.Spec.LiteralSubject = "CN=example.com,O=Example Inc."
+
+	m.Resync(ResyncState{Certificates: []*cmapi.Certificate{
+		gen.Certificate("crt1", gen.SetCertificateCommonName("example.com")),
+		withSubject,
+		withOrganizations,
+		withLiteralSubject,
+	}})
+	if err := testutil.CollectAndCompare(m.certificateSubjectFieldCount,
+		strings.NewReader(subjectFieldMetadata+`
+	certmanager_certificate_subject_field_count{field="country"} 1
+	certmanager_certificate_subject_field_count{field="literal_subject"} 1
+	certmanager_certificate_subject_field_count{field="locality"} 0
+	certmanager_certificate_subject_field_count{field="organization"} 2
+	certmanager_certificate_subject_field_count{field="organizational_unit"} 0
+	certmanager_certificate_subject_field_count{field="postal_code"} 0
+	certmanager_certificate_subject_field_count{field="province"} 0
+	certmanager_certificate_subject_field_count{field="serial_number"} 1
+	certmanager_certificate_subject_field_count{field="street_address"} 0
+`),
+		"certmanager_certificate_subject_field_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const gatedFeatureBlockedMetadata = `
+	# HELP certmanager_certificate_gated_feature_blocked_count The number of Certificates which use a field that is ignored because the feature gate it requires is disabled.
+	# TYPE certmanager_certificate_gated_feature_blocked_count gauge
+`
+
+func TestSetCertificateGatedFeatureBlockedCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	m.SetCertificateGatedFeatureBlockedCount(map[string]int{
+		"AdditionalCertificateOutputFormats": 0,
+		"LiteralCertificateSubject":          2,
+	})
+	if err := testutil.CollectAndCompare(m.certificateGatedFeatureBlockedCount,
+		strings.NewReader(gatedFeatureBlockedMetadata+`
+	certmanager_certificate_gated_feature_blocked_count{feature="AdditionalCertificateOutputFormats"} 0
+	certmanager_certificate_gated_feature_blocked_count{feature="LiteralCertificateSubject"} 2
+`),
+		"certmanager_certificate_gated_feature_blocked_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// countScrapes wraps the metrics handler, counting each request it serves
+// in the metrics_scrape_count metric.
+func (m *Metrics) countScrapes(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.metricsScrapeCount.WithLabelValues(scrapeSource(r.RemoteAddr)).Inc()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// countTimeouts wraps the metrics handler, counting the requests which are
+// cut off by the metrics server's write timeout in the
+// metrics_request_timeout_count metric. The server does not cancel requests
+// when the timeout expires, but any response written afterwards is lost, so
+// the request context is given a deadline of the same timeout.
+func (m *Metrics) countTimeouts(next http.Handler, timeout time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			m.metricsRequestTimeoutCount.Inc()
+		}
+	})
+}
+
+// scrapeSource classifies the IP address of a scraper as `loopback`,
+// `private` or `public`, to avoid exposing a series per scraper. Addresses
+// which cannot be parsed are reported as `unknown`.
+func scrapeSource(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	ip := net.ParseIP(host)
+	switch {
+	case ip == nil:
+		return "unknown"
+	case ip.IsLoopback():
+		return "loopback"
+	case ip.IsPrivate(), ip.IsLinkLocalUnicast():
+		return "private"
+	default:
+		return "public"
+	}
+}
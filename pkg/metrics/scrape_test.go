@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/clock"
+)
+
+const scrapeCountMetadata = `
+	# HELP certmanager_metrics_scrape_count The number of requests served by the metrics endpoint, by the class of the scraper's IP address.
+	# TYPE certmanager_metrics_scrape_count counter
+`
+
+func TestCountScrapes(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+	handler := m.countScrapes(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	for _, remoteAddr := range []string{
+		"127.0.0.1:1234",
+		"[::1]:1234",
+		"10.0.0.1:1234",
+		"192.168.1.1:1234",
+		"8.8.8.8:1234",
+		"not an address",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.RemoteAddr = remoteAddr
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if err := testutil.CollectAndCompare(m.metricsScrapeCount,
+		strings.NewReader(scrapeCountMetadata+`
+	certmanager_metrics_scrape_count{source="loopback"} 2
+	certmanager_metrics_scrape_count{source="private"} 2
+	certmanager_metrics_scrape_count{source="public"} 1
+	certmanager_metrics_scrape_count{source="unknown"} 1
+`),
+		"certmanager_metrics_scrape_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestCountTimeouts(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	// The slow handler only returns once the request context is done.
+	slow := m.countTimeouts(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}), 10*time.Millisecond)
+	fast := m.countTimeouts(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), time.Minute)
+
+	slow.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	fast.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	// Requests cancelled by the scraper disconnecting are not timeouts.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	slow.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics", nil).WithContext(ctx))
+
+	if got := testutil.ToFloat64(m.metricsRequestTimeoutCount); got != 1 {
+		t.Errorf("expected 1 timed out request, got %v", got)
+	}
+}
+
+func TestMetricsHandlerCountsScrapes(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	rec := httptest.NewRecorder()
+	m.MetricsHandler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if got := testutil.ToFloat64(m.metricsScrapeCount.WithLabelValues("private")); got != 1 {
+		t.Errorf("expected 1 scrape to be counted, got %v", got)
+	}
+}
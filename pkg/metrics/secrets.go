@@ -0,0 +1,385 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// certManagerFieldManagerPrefix is the prefix of the field managers used by
+// cert-manager's components, which are derived from their User-Agent, e.g.
+// `cert-manager-certificates-issuing`.
+const certManagerFieldManagerPrefix = "cert-manager"
+
+// defaultMinimumRSAKeySize is the size, in bits, of an RSA key below which
+// it is counted as weak, unless configured otherwise.
+const defaultMinimumRSAKeySize = 2048
+
+// chainExpiringSoonWindow is the time before a CA certificate in a
+// Certificate's Secret expires within which it is counted as expiring soon.
+const chainExpiringSoonWindow = 30 * 24 * time.Hour
+
+// minimumECDSAKeySize is the size, in bits, of an ECDSA key's curve below
+// which it is counted as weak.
+const minimumECDSAKeySize = 256
+
+// certManagerSecretDataKeys are the Secret data keys written by cert-manager.
+var certManagerSecretDataKeys = []string{
+	corev1.TLSCertKey,
+	corev1.TLSPrivateKeyKey,
+	cmmeta.TLSCAKey,
+}
+
+// certificateSecret is the Secret referenced by a Certificate, along with
+// its decoded certificate chain.
+type certificateSecret struct {
+	secret *corev1.Secret
+
+	// chain is the decoded tls.crt, leaf first. It is nil if the Secret does
+	// not contain a tls.crt, or if it could not be decoded.
+	chain []*x509.Certificate
+
+	// ca is the decoded ca.crt. It is nil if the Secret does not contain a
+	// ca.crt, or if it could not be decoded.
+	ca []*x509.Certificate
+
+	// key is the decoded tls.key. It is nil if the Secret does not contain a
+	// tls.key, or if it could not be decoded.
+	key crypto.Signer
+}
+
+// certificateSecrets looks up the Secret referenced by each Certificate and
+// decodes its tls.crt and tls.key. Certificates whose Secret does not exist
+// are omitted from the returned map. Secrets which fail to decode are counted
+// in the certificate_secret_parse_error_count metric, and are returned without
+// the chain or key which failed to decode. A ca.crt which fails to decode is
+// ignored.
+func (m *Metrics) certificateSecrets(crts []*cmapi.Certificate, secrets []*corev1.Secret) map[*cmapi.Certificate]certificateSecret {
+	secretsByName := make(map[types.NamespacedName]*corev1.Secret, len(secrets))
+	for _, secret := range secrets {
+		secretsByName[types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}] = secret
+	}
+
+	result := make(map[*cmapi.Certificate]certificateSecret)
+	for _, crt := range crts {
+		secret, ok := secretsByName[types.NamespacedName{Namespace: crt.Namespace, Name: crt.Spec.SecretName}]
+		if !ok {
+			continue
+		}
+
+		crtSecret := certificateSecret{secret: secret}
+		if certData := secret.Data[corev1.TLSCertKey]; len(certData) > 0 {
+			chain, err := pki.DecodeX509CertificateChainBytes(certData)
+			if err != nil {
+				logf.WithRelatedResource(m.log, secret).V(logf.DebugLevel).Info("failed to decode certificate in Secret", "error", err)
+				m.certificateSecretParseErrorCount.WithLabelValues(secret.Namespace).Inc()
+			} else {
+				crtSecret.chain = chain
+			}
+		}
+
+		if keyData := secret.Data[corev1.TLSPrivateKeyKey]; len(keyData) > 0 {
+			key, err := pki.DecodePrivateKeyBytes(keyData)
+			if err != nil {
+				logf.WithRelatedResource(m.log, secret).V(logf.DebugLevel).Info("failed to decode private key in Secret", "error", err)
+				m.certificateSecretParseErrorCount.WithLabelValues(secret.Namespace).Inc()
+			} else {
+				crtSecret.key = key
+			}
+		}
+
+		if caData := secret.Data[cmmeta.TLSCAKey]; len(caData) > 0 {
+			ca, err := pki.DecodeX509CertificateChainBytes(caData)
+			if err != nil {
+				logf.WithRelatedResource(m.log, secret).V(logf.DebugLevel).Info("failed to decode CA certificate in Secret", "error", err)
+			} else {
+				crtSecret.ca = ca
+			}
+		}
+
+		result[crt] = crtSecret
+	}
+
+	return result
+}
+
+// updateCertificateDistinctIssuersInChain sets the number of distinct issuers
+// across the decoded tls.crt and ca.crt of each Certificate's Secret.
+// Certificates whose Secret could not be decoded are not exposed.
+func (m *Metrics) updateCertificateDistinctIssuersInChain(crtSecrets map[*cmapi.Certificate]certificateSecret) {
+	values := newGaugeValues()
+
+	for crt, crtSecret := range crtSecrets {
+		if len(crtSecret.chain) == 0 {
+			continue
+		}
+
+		issuers := make(map[string]struct{})
+		for _, cert := range crtSecret.chain {
+			issuers[string(cert.RawIssuer)] = struct{}{}
+		}
+		for _, cert := range crtSecret.ca {
+			issuers[string(cert.RawIssuer)] = struct{}{}
+		}
+
+		values.set(float64(len(issuers)), crt.Name, crt.Namespace)
+	}
+
+	m.setGaugeValues(m.certificateDistinctIssuersInChain, values)
+}
+
+// updateCertificateKeyCertMismatchCount exposes each Certificate whose
+// Secret contains a private key which does not match the public key of the
+// leaf certificate. Certificates whose Secret is missing either the key or
+// the certificate, or where either could not be decoded, are not exposed.
+func (m *Metrics) updateCertificateKeyCertMismatchCount(crtSecrets map[*cmapi.Certificate]certificateSecret) {
+	values := newGaugeValues()
+
+	for crt, crtSecret := range crtSecrets {
+		if len(crtSecret.chain) == 0 || crtSecret.key == nil {
+			continue
+		}
+
+		matches, err := pki.PublicKeyMatchesCertificate(crtSecret.key.Public(), crtSecret.chain[0])
+		if err != nil {
+			logf.WithRelatedResource(m.log, crtSecret.secret).V(logf.DebugLevel).Info("failed to compare private key with certificate in Secret", "error", err)
+			continue
+		}
+		if !matches {
+			values.set(1, crt.Namespace, crt.Name)
+		}
+	}
+
+	m.setGaugeValues(m.certificateKeyCertMismatchCount, values)
+}
+
+// updateCertificateSecretMultiManagedCount counts the Certificates' Secrets
+// in each namespace where a field manager other than cert-manager manages
+// one of the data keys written by cert-manager.
+func (m *Metrics) updateCertificateSecretMultiManagedCount(crtSecrets map[*cmapi.Certificate]certificateSecret) {
+	values := newGaugeValues()
+
+	// More than one Certificate may reference the same Secret, which should
+	// only be counted once.
+	seen := make(map[*corev1.Secret]struct{})
+	for _, crtSecret := range crtSecrets {
+		if _, ok := seen[crtSecret.secret]; ok {
+			continue
+		}
+		seen[crtSecret.secret] = struct{}{}
+
+		if m.secretDataManagedByOthers(crtSecret.secret) {
+			values.inc(crtSecret.secret.Namespace)
+		}
+	}
+
+	m.setGaugeValues(m.certificateSecretMultiManagedCount, values)
+}
+
+// updateCertificateWeakKeyCount counts the Certificates in each namespace
+// whose leaf certificate has a public key below the minimum strength for its
+// algorithm. Certificates whose Secret could not be decoded are not counted.
+func (m *Metrics) updateCertificateWeakKeyCount(crtSecrets map[*cmapi.Certificate]certificateSecret) {
+	values := newGaugeValues()
+
+	for crt, crtSecret := range crtSecrets {
+		if len(crtSecret.chain) == 0 {
+			continue
+		}
+
+		if algorithm, weak := m.weakPublicKey(crtSecret.chain[0].PublicKey); weak {
+			values.inc(string(algorithm), crt.Namespace)
+		}
+	}
+
+	m.setGaugeValues(m.certificateWeakKeyCount, values)
+}
+
+// weakPublicKey returns the algorithm of the given public key, and whether
+// the key is below the minimum strength for that algorithm. Ed25519 keys are
+// never weak.
+func (m *Metrics) weakPublicKey(pub crypto.PublicKey) (cmapi.PrivateKeyAlgorithm, bool) {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return cmapi.RSAKeyAlgorithm, pub.N.BitLen() < m.opts.minimumRSAKeySize
+	case *ecdsa.PublicKey:
+		return cmapi.ECDSAKeyAlgorithm, pub.Curve.Params().BitSize < minimumECDSAKeySize
+	case ed25519.PublicKey:
+		return cmapi.Ed25519KeyAlgorithm, false
+	default:
+		return "", false
+	}
+}
+
+// updateCertificateMissingCACrtCount counts the Certificates whose Secret
+// contains a tls.crt but no ca.crt, by the kind and group of their issuer.
+// Secrets without a tls.crt have not been issued, and are not counted.
+func (m *Metrics) updateCertificateMissingCACrtCount(crtSecrets map[*cmapi.Certificate]certificateSecret) {
+	values := newGaugeValues()
+
+	for crt, crtSecret := range crtSecrets {
+		if len(crtSecret.secret.Data[corev1.TLSCertKey]) == 0 || len(crtSecret.secret.Data[cmmeta.TLSCAKey]) > 0 {
+			continue
+		}
+
+		values.inc(crt.Spec.IssuerRef.Kind, crt.Spec.IssuerRef.Group)
+	}
+
+	m.setGaugeValues(m.certificateMissingCACrtCount, values)
+}
+
+// updateCertificateChainExpiringSoonCount counts the Certificates whose Secret
+// contains a CA certificate which has expired or will expire within
+// chainExpiringSoonWindow, by the kind and group of their issuer. Both the
+// CA certificates following the leaf in tls.crt and those in ca.crt are
+// checked. A Certificate is counted once, however many of its CA
+// certificates are expiring.
+func (m *Metrics) updateCertificateChainExpiringSoonCount(crtSecrets map[*cmapi.Certificate]certificateSecret) {
+	values := newGaugeValues()
+
+	cutoff := m.clock.Now().Add(chainExpiringSoonWindow)
+	for crt, crtSecret := range crtSecrets {
+		if len(crtSecret.chain) == 0 {
+			continue
+		}
+
+		var cas []*x509.Certificate
+		cas = append(cas, crtSecret.chain[1:]...)
+		cas = append(cas, crtSecret.ca...)
+		for _, ca := range cas {
+			if ca.NotAfter.Before(cutoff) {
+				values.inc(crt.Spec.IssuerRef.Kind, crt.Spec.IssuerRef.Group)
+				break
+			}
+		}
+	}
+
+	m.setGaugeValues(m.certificateChainExpiringSoonCount, values)
+}
+
+// updateCertificateImmutableSecretCount counts the Certificates' Secrets by
+// whether they are immutable. Both label values are always reported.
+func (m *Metrics) updateCertificateImmutableSecretCount(crtSecrets map[*cmapi.Certificate]certificateSecret) {
+	values := newGaugeValues()
+
+	// More than one Certificate may reference the same Secret, which should
+	// only be counted once.
+	seen := make(map[*corev1.Secret]struct{})
+	var immutable, mutable int
+	for _, crtSecret := range crtSecrets {
+		if _, ok := seen[crtSecret.secret]; ok {
+			continue
+		}
+		seen[crtSecret.secret] = struct{}{}
+
+		if crtSecret.secret.Immutable != nil && *crtSecret.secret.Immutable {
+			immutable++
+		} else {
+			mutable++
+		}
+	}
+
+	values.set(float64(immutable), "true")
+	values.set(float64(mutable), "false")
+
+	m.setGaugeValues(m.certificateImmutableSecretCount, values)
+}
+
+// secretDataManagedByOthers returns true if a field manager which is not
+// part of cert-manager manages any of the data keys written by cert-manager.
+func (m *Metrics) secretDataManagedByOthers(secret *corev1.Secret) bool {
+	for _, entry := range secret.ManagedFields {
+		if strings.HasPrefix(entry.Manager, certManagerFieldManagerPrefix) || entry.FieldsV1 == nil {
+			continue
+		}
+
+		var fields struct {
+			Data map[string]json.RawMessage `json:"f:data"`
+		}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			logf.WithRelatedResource(m.log, secret).V(logf.DebugLevel).Info("failed to decode managed fields of Secret", "manager", entry.Manager, "error", err)
+			continue
+		}
+
+		for _, key := range certManagerSecretDataKeys {
+			if _, ok := fields.Data["f:"+key]; ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// updateCertificateIssuerRefDriftCount counts the Certificates whose Secret
+// was issued by a different issuer than the one in the Certificate's
+// issuerRef, as recorded by the issuer annotations on the Secret. These
+// Certificates are migrating to a new issuer and will be re-issued. Secrets
+// without issuer annotations are not counted.
+func (m *Metrics) updateCertificateIssuerRefDriftCount(crtSecrets map[*cmapi.Certificate]certificateSecret) {
+	values := newGaugeValues()
+
+	for crt, crtSecret := range crtSecrets {
+		annotations := crtSecret.secret.Annotations
+		name, ok1 := annotations[cmapi.IssuerNameAnnotationKey]
+		kind, ok2 := annotations[cmapi.IssuerKindAnnotationKey]
+		group, ok3 := annotations[cmapi.IssuerGroupAnnotationKey]
+		if !ok1 && !ok2 && !ok3 {
+			continue
+		}
+
+		ref := crt.Spec.IssuerRef
+		if name != ref.Name || defaultIssuerKind(kind) != defaultIssuerKind(ref.Kind) || defaultIssuerGroup(group) != defaultIssuerGroup(ref.Group) {
+			values.inc(crt.Namespace)
+		}
+	}
+
+	m.setGaugeValues(m.certificateIssuerRefDriftCount, values)
+}
+
+// defaultIssuerKind returns the given issuer kind, or Issuer if it is empty.
+func defaultIssuerKind(kind string) string {
+	if kind == "" {
+		return cmapi.IssuerKind
+	}
+	return kind
+}
+
+// defaultIssuerGroup returns the given issuer group, or cert-manager.io if it
+// is empty.
+func defaultIssuerGroup(group string) string {
+	if group == "" {
+		return certmanager.GroupName
+	}
+	return group
+}
@@ -0,0 +1,658 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+const secretParseErrorMetadata = `
+	# HELP certmanager_certificate_secret_parse_error_count The number of times a Certificate's Secret could not be parsed while computing metrics.
+	# TYPE certmanager_certificate_secret_parse_error_count counter
+`
+
+func testSecret(name, namespace string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       data,
+	}
+}
+
+func TestCertificateSecrets(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crtWithSecret := func(name, namespace string) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace(namespace),
+			gen.SetCertificateSecretName(name+"-tls"),
+			gen.SetCertificateCommonName("example.com"),
+		)
+	}
+
+	validCrt := crtWithSecret("valid", "ns1")
+	certPEM := testcrypto.MustCreateCert(t, testcrypto.MustCreatePEMPrivateKey(t), validCrt)
+	invalidCrt := crtWithSecret("invalid", "ns1")
+	emptyCrt := crtWithSecret("empty", "ns2")
+	missingCrt := crtWithSecret("missing", "ns2")
+
+	crtSecrets := m.certificateSecrets(
+		[]*cmapi.Certificate{validCrt, invalidCrt, emptyCrt, missingCrt},
+		[]*corev1.Secret{
+			testSecret("valid-tls", "ns1", map[string][]byte{corev1.TLSCertKey: certPEM}),
+			testSecret("invalid-tls", "ns1", map[string][]byte{corev1.TLSCertKey: []byte("not a certificate")}),
+			testSecret("empty-tls", "ns2", nil),
+			// A Secret with the same name in a different namespace should
+			// not be matched.
+			testSecret("missing-tls", "ns1", nil),
+		},
+	)
+
+	if len(crtSecrets) != 3 {
+		t.Errorf("expected 3 Certificates to have a Secret, got %d", len(crtSecrets))
+	}
+	if got := crtSecrets[validCrt].chain; len(got) != 1 || got[0].Subject.CommonName != "example.com" {
+		t.Errorf("expected valid Secret to be decoded, got %v", got)
+	}
+	if got := crtSecrets[invalidCrt]; got.secret == nil || got.chain != nil {
+		t.Errorf("expected invalid Secret to be returned without a chain, got %+v", got)
+	}
+	if got := crtSecrets[emptyCrt]; got.secret == nil || got.chain != nil {
+		t.Errorf("expected empty Secret to be returned without a chain, got %+v", got)
+	}
+	if _, ok := crtSecrets[missingCrt]; ok {
+		t.Errorf("expected missing Secret not to be returned")
+	}
+
+	if err := testutil.CollectAndCompare(m.certificateSecretParseErrorCount,
+		strings.NewReader(secretParseErrorMetadata+`
+	certmanager_certificate_secret_parse_error_count{namespace="ns1"} 1
+`),
+		"certmanager_certificate_secret_parse_error_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const distinctIssuersInChainMetadata = `
+	# HELP certmanager_certificate_distinct_issuers_in_chain The number of distinct issuers in the certificate chain and CA stored in a Certificate's Secret.
+	# TYPE certmanager_certificate_distinct_issuers_in_chain gauge
+`
+
+func TestResyncCertificateDistinctIssuersInChain(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	// Certificates created by MustCreateCert are self-signed, so the issuer
+	// is the same as the common name.
+	certPEM := func(commonName string) []byte {
+		return testcrypto.MustCreateCert(t, testcrypto.MustCreatePEMPrivateKey(t),
+			gen.Certificate("test", gen.SetCertificateCommonName(commonName)))
+	}
+	crtWithSecret := func(name string) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateSecretName(name+"-tls"),
+		)
+	}
+
+	m.Resync(ResyncState{
+		Certificates: []*cmapi.Certificate{
+			crtWithSecret("crt1"),
+			crtWithSecret("crt2"),
+			crtWithSecret("crt3"),
+		},
+		Secrets: []*corev1.Secret{
+			testSecret("crt1-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey: append(certPEM("leaf"), certPEM("intermediate")...),
+				cmmeta.TLSCAKey:   append(certPEM("root"), certPEM("intermediate")...),
+			}),
+			// A ca.crt which fails to decode is ignored.
+			testSecret("crt2-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey: certPEM("leaf"),
+				cmmeta.TLSCAKey:   []byte("not a certificate"),
+			}),
+			// Certificates whose tls.crt fails to decode are not exposed.
+			testSecret("crt3-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey: []byte("not a certificate"),
+			}),
+		},
+	})
+	if err := testutil.CollectAndCompare(m.certificateDistinctIssuersInChain,
+		strings.NewReader(distinctIssuersInChainMetadata+`
+	certmanager_certificate_distinct_issuers_in_chain{name="crt1",namespace="test-ns"} 3
+	certmanager_certificate_distinct_issuers_in_chain{name="crt2",namespace="test-ns"} 1
+`),
+		"certmanager_certificate_distinct_issuers_in_chain",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const keyCertMismatchMetadata = `
+	# HELP certmanager_certificate_key_cert_mismatch_count Whether the private key stored in a Certificate's Secret does not match the public key of its certificate. Only mismatched Certificates are exposed.
+	# TYPE certmanager_certificate_key_cert_mismatch_count gauge
+`
+
+func TestResyncCertificateKeyCertMismatchCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crtWithSecret := func(name string) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateSecretName(name+"-tls"),
+			gen.SetCertificateCommonName("example.com"),
+		)
+	}
+	keyPEM := testcrypto.MustCreatePEMPrivateKey(t)
+	otherKeyPEM := testcrypto.MustCreatePEMPrivateKey(t)
+	certPEM := testcrypto.MustCreateCert(t, keyPEM, crtWithSecret("test"))
+
+	crts := []*cmapi.Certificate{
+		crtWithSecret("matching"),
+		crtWithSecret("mismatched"),
+		crtWithSecret("invalid-key"),
+		crtWithSecret("no-key"),
+	}
+	m.Resync(ResyncState{
+		Certificates: crts,
+		Secrets: []*corev1.Secret{
+			testSecret("matching-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey:       certPEM,
+				corev1.TLSPrivateKeyKey: keyPEM,
+			}),
+			testSecret("mismatched-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey:       certPEM,
+				corev1.TLSPrivateKeyKey: otherKeyPEM,
+			}),
+			// Secrets whose tls.key fails to decode are not exposed.
+			testSecret("invalid-key-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey:       certPEM,
+				corev1.TLSPrivateKeyKey: []byte("not a key"),
+			}),
+			testSecret("no-key-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey: certPEM,
+			}),
+		},
+	})
+	if err := testutil.CollectAndCompare(m.certificateKeyCertMismatchCount,
+		strings.NewReader(keyCertMismatchMetadata+`
+	certmanager_certificate_key_cert_mismatch_count{name="mismatched",namespace="test-ns"} 1
+`),
+		"certmanager_certificate_key_cert_mismatch_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Resolved mismatches should no longer be exposed.
+	m.Resync(ResyncState{
+		Certificates: crts,
+		Secrets: []*corev1.Secret{
+			testSecret("mismatched-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey:       certPEM,
+				corev1.TLSPrivateKeyKey: keyPEM,
+			}),
+		},
+	})
+	if err := testutil.CollectAndCompare(m.certificateKeyCertMismatchCount,
+		strings.NewReader(keyCertMismatchMetadata),
+		"certmanager_certificate_key_cert_mismatch_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const secretMultiManagedMetadata = `
+	# HELP certmanager_certificate_secret_multimanaged_count The number of Certificates' Secrets whose certificate or private key data is also managed by a field manager other than cert-manager.
+	# TYPE certmanager_certificate_secret_multimanaged_count gauge
+`
+
+func TestResyncCertificateSecretMultiManagedCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crtWithSecret := func(name, namespace string) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace(namespace),
+			gen.SetCertificateSecretName(name+"-tls"),
+		)
+	}
+	secretManagedBy := func(name, namespace string, managedFields ...metav1.ManagedFieldsEntry) *corev1.Secret {
+		secret := testSecret(name, namespace, nil)
+		secret.ManagedFields = managedFields
+		return secret
+	}
+	managedFields := func(manager, fields string) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:  manager,
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(fields)},
+		}
+	}
+
+	m.Resync(ResyncState{
+		Certificates: []*cmapi.Certificate{
+			crtWithSecret("crt1", "ns1"),
+			crtWithSecret("crt2", "ns1"),
+			crtWithSecret("crt3", "ns1"),
+			crtWithSecret("crt4", "ns2"),
+		},
+		Secrets: []*corev1.Secret{
+			// Only managed by cert-manager.
+			secretManagedBy("crt1-tls", "ns1",
+				managedFields("cert-manager-certificates-issuing", `{"f:data":{"f:tls.crt":{},"f:tls.key":{}}}`),
+			),
+			// Another manager also writes the certificate.
+			secretManagedBy("crt2-tls", "ns1",
+				managedFields("cert-manager-certificates-issuing", `{"f:data":{"f:tls.crt":{},"f:tls.key":{}}}`),
+				managedFields("kubectl-edit", `{"f:data":{"f:tls.crt":{}}}`),
+			),
+			// Other managers only manage fields not written by cert-manager.
+			secretManagedBy("crt3-tls", "ns1",
+				managedFields("kubectl-edit", `{"f:data":{"f:extra":{}},"f:metadata":{"f:labels":{}}}`),
+			),
+			secretManagedBy("crt4-tls", "ns2",
+				managedFields("external-secrets", `{"f:data":{"f:ca.crt":{}}}`),
+			),
+		},
+	})
+	if err := testutil.CollectAndCompare(m.certificateSecretMultiManagedCount,
+		strings.NewReader(secretMultiManagedMetadata+`
+	certmanager_certificate_secret_multimanaged_count{namespace="ns1"} 1
+	certmanager_certificate_secret_multimanaged_count{namespace="ns2"} 1
+`),
+		"certmanager_certificate_secret_multimanaged_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const weakKeyMetadata = `
+	# HELP certmanager_certificate_weak_key_count The number of Certificates whose issued certificate has a public key below the minimum strength for its algorithm, by algorithm and namespace.
+	# TYPE certmanager_certificate_weak_key_count gauge
+`
+
+func TestResyncCertificateWeakKeyCount(t *testing.T) {
+	crtWithSecret := func(name, namespace string) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace(namespace),
+			gen.SetCertificateSecretName(name+"-tls"),
+			gen.SetCertificateCommonName("example.com"),
+		)
+	}
+
+	signer, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// mustCreateCert returns a certificate for the given public key, signed
+	// by an RSA key so that curves which cannot sign are supported.
+	mustCreateCert := func(pub crypto.PublicKey) []byte {
+		template, err := pki.CertificateTemplateFromCertificate(crtWithSecret("test", "test-ns"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		certPEM, _, err := pki.SignCertificate(template, template, pub, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return certPEM
+	}
+	mustCreateECDSAKey := func(curve elliptic.Curve) crypto.PublicKey {
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return key.Public()
+	}
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crts := []*cmapi.Certificate{
+		crtWithSecret("rsa-2048", "ns-1"),
+		crtWithSecret("rsa-2048", "ns-2"),
+		crtWithSecret("ecdsa-p224", "ns-1"),
+		crtWithSecret("ecdsa-p256", "ns-1"),
+		crtWithSecret("ed25519", "ns-1"),
+	}
+	secrets := []*corev1.Secret{
+		testSecret("rsa-2048-tls", "ns-1", map[string][]byte{corev1.TLSCertKey: mustCreateCert(signer.Public())}),
+		testSecret("rsa-2048-tls", "ns-2", map[string][]byte{corev1.TLSCertKey: mustCreateCert(signer.Public())}),
+		testSecret("ecdsa-p224-tls", "ns-1", map[string][]byte{corev1.TLSCertKey: mustCreateCert(mustCreateECDSAKey(elliptic.P224()))}),
+		testSecret("ecdsa-p256-tls", "ns-1", map[string][]byte{corev1.TLSCertKey: mustCreateCert(mustCreateECDSAKey(elliptic.P256()))}),
+		testSecret("ed25519-tls", "ns-1", map[string][]byte{corev1.TLSCertKey: mustCreateCert(edKey)}),
+	}
+
+	tests := map[string]struct {
+		opts     []Option
+		expected string
+	}{
+		"RSA keys of the default minimum size should not be counted": {
+			expected: `
+	certmanager_certificate_weak_key_count{algorithm="ECDSA",namespace="ns-1"} 1
+`,
+		},
+		"RSA keys below a configured minimum size should be counted": {
+			opts: []Option{WithMinimumRSAKeySize(3072)},
+			expected: `
+	certmanager_certificate_weak_key_count{algorithm="ECDSA",namespace="ns-1"} 1
+	certmanager_certificate_weak_key_count{algorithm="RSA",namespace="ns-1"} 1
+	certmanager_certificate_weak_key_count{algorithm="RSA",namespace="ns-2"} 1
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := New(logtesting.NewTestLogger(t), clock.RealClock{}, test.opts...)
+
+			m.Resync(ResyncState{Certificates: crts, Secrets: secrets})
+			if err := testutil.CollectAndCompare(m.certificateWeakKeyCount,
+				strings.NewReader(weakKeyMetadata+test.expected),
+				"certmanager_certificate_weak_key_count",
+			); err != nil {
+				t.Errorf("unexpected collecting result:\n%s", err)
+			}
+
+			// Counts should be reset on each resync.
+			m.Resync(ResyncState{})
+			if err := testutil.CollectAndCompare(m.certificateWeakKeyCount,
+				strings.NewReader(weakKeyMetadata),
+				"certmanager_certificate_weak_key_count",
+			); err != nil {
+				t.Errorf("unexpected collecting result:\n%s", err)
+			}
+		})
+	}
+}
+
+const missingCACrtMetadata = `
+	# HELP certmanager_certificate_missing_ca_crt_count The number of issued Certificates whose Secret does not contain a ca.crt, by issuer kind and group.
+	# TYPE certmanager_certificate_missing_ca_crt_count gauge
+`
+
+func TestResyncCertificateMissingCACrtCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crtWithIssuer := func(name, kind, group string) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateSecretName(name+"-tls"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer", Kind: kind, Group: group}),
+		)
+	}
+
+	crts := []*cmapi.Certificate{
+		crtWithIssuer("with-ca", "Issuer", "cert-manager.io"),
+		crtWithIssuer("without-ca-1", "Issuer", "cert-manager.io"),
+		crtWithIssuer("without-ca-2", "Issuer", "cert-manager.io"),
+		crtWithIssuer("external-without-ca", "ExternalIssuer", "example.com"),
+		crtWithIssuer("not-issued", "Issuer", "cert-manager.io"),
+	}
+	m.Resync(ResyncState{
+		Certificates: crts,
+		Secrets: []*corev1.Secret{
+			testSecret("with-ca-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey: []byte("cert"),
+				cmmeta.TLSCAKey:   []byte("ca"),
+			}),
+			testSecret("without-ca-1-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey: []byte("cert"),
+			}),
+			// An empty ca.crt is counted as missing.
+			testSecret("without-ca-2-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey: []byte("cert"),
+				cmmeta.TLSCAKey:   {},
+			}),
+			testSecret("external-without-ca-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey: []byte("cert"),
+			}),
+			testSecret("not-issued-tls", "test-ns", nil),
+		},
+	})
+	if err := testutil.CollectAndCompare(m.certificateMissingCACrtCount,
+		strings.NewReader(missingCACrtMetadata+`
+	certmanager_certificate_missing_ca_crt_count{issuer_group="cert-manager.io",issuer_kind="Issuer"} 2
+	certmanager_certificate_missing_ca_crt_count{issuer_group="example.com",issuer_kind="ExternalIssuer"} 1
+`),
+		"certmanager_certificate_missing_ca_crt_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Counts should be reset on each resync.
+	m.Resync(ResyncState{})
+	if err := testutil.CollectAndCompare(m.certificateMissingCACrtCount,
+		strings.NewReader(missingCACrtMetadata),
+		"certmanager_certificate_missing_ca_crt_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const immutableSecretMetadata = `
+	# HELP certmanager_certificate_immutable_secret_count The number of Certificates' Secrets, by whether the Secret is immutable.
+	# TYPE certmanager_certificate_immutable_secret_count gauge
+`
+
+func TestResyncCertificateImmutableSecretCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crtWithSecret := func(name, secretName string) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateSecretName(secretName),
+		)
+	}
+	secretWithImmutable := func(name string, immutable *bool) *corev1.Secret {
+		secret := testSecret(name, "test-ns", nil)
+		secret.Immutable = immutable
+		return secret
+	}
+	immutable, mutable := true, false
+
+	m.Resync(ResyncState{
+		Certificates: []*cmapi.Certificate{
+			crtWithSecret("crt1", "immutable-tls"),
+			// Secrets referenced by more than one Certificate are counted
+			// once.
+			crtWithSecret("crt2", "immutable-tls"),
+			crtWithSecret("crt3", "mutable-tls"),
+			crtWithSecret("crt4", "unset-tls"),
+		},
+		Secrets: []*corev1.Secret{
+			secretWithImmutable("immutable-tls", &immutable),
+			secretWithImmutable("mutable-tls", &mutable),
+			secretWithImmutable("unset-tls", nil),
+		},
+	})
+	if err := testutil.CollectAndCompare(m.certificateImmutableSecretCount,
+		strings.NewReader(immutableSecretMetadata+`
+	certmanager_certificate_immutable_secret_count{immutable="false"} 2
+	certmanager_certificate_immutable_secret_count{immutable="true"} 1
+`),
+		"certmanager_certificate_immutable_secret_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	m.Resync(ResyncState{})
+	if err := testutil.CollectAndCompare(m.certificateImmutableSecretCount,
+		strings.NewReader(immutableSecretMetadata+`
+	certmanager_certificate_immutable_secret_count{immutable="false"} 0
+	certmanager_certificate_immutable_secret_count{immutable="true"} 0
+`),
+		"certmanager_certificate_immutable_secret_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const chainExpiringSoonMetadata = `
+	# HELP certmanager_certificate_chain_expiring_soon_count The number of Certificates whose Secret contains a CA certificate which expires within 30 days, by the kind and group of their issuer.
+	# TYPE certmanager_certificate_chain_expiring_soon_count gauge
+`
+
+func TestResyncCertificateChainExpiringSoonCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	certPEM := func(duration time.Duration) []byte {
+		return testcrypto.MustCreateCert(t, testcrypto.MustCreatePEMPrivateKey(t),
+			gen.Certificate("test", gen.SetCertificateCommonName("test"), gen.SetCertificateDuration(duration)))
+	}
+	crtWithIssuer := func(name, kind, group string) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateSecretName(name+"-tls"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer", Kind: kind, Group: group}),
+		)
+	}
+	const (
+		soon  = 7 * 24 * time.Hour
+		later = 365 * 24 * time.Hour
+	)
+
+	m.Resync(ResyncState{
+		Certificates: []*cmapi.Certificate{
+			crtWithIssuer("expiring-intermediate", "Issuer", "cert-manager.io"),
+			crtWithIssuer("expiring-ca", "Issuer", "cert-manager.io"),
+			crtWithIssuer("expiring-both", "ClusterIssuer", "cert-manager.io"),
+			crtWithIssuer("not-expiring", "Issuer", "cert-manager.io"),
+			crtWithIssuer("leaf-only", "Issuer", "cert-manager.io"),
+			crtWithIssuer("external", "ExternalIssuer", "example.com"),
+		},
+		Secrets: []*corev1.Secret{
+			testSecret("expiring-intermediate-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey: append(certPEM(later), certPEM(soon)...),
+				cmmeta.TLSCAKey:   certPEM(later),
+			}),
+			testSecret("expiring-ca-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey: certPEM(later),
+				cmmeta.TLSCAKey:   certPEM(soon),
+			}),
+			// A Certificate is counted once however many CAs are expiring.
+			testSecret("expiring-both-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey: append(certPEM(later), certPEM(soon)...),
+				cmmeta.TLSCAKey:   certPEM(soon),
+			}),
+			testSecret("not-expiring-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey: append(certPEM(later), certPEM(later)...),
+				cmmeta.TLSCAKey:   certPEM(later),
+			}),
+			// The expiry of the leaf itself is not considered.
+			testSecret("leaf-only-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey: certPEM(soon),
+			}),
+			testSecret("external-tls", "test-ns", map[string][]byte{
+				corev1.TLSCertKey: append(certPEM(later), certPEM(soon)...),
+			}),
+		},
+	})
+	if err := testutil.CollectAndCompare(m.certificateChainExpiringSoonCount,
+		strings.NewReader(chainExpiringSoonMetadata+`
+	certmanager_certificate_chain_expiring_soon_count{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer"} 1
+	certmanager_certificate_chain_expiring_soon_count{issuer_group="cert-manager.io",issuer_kind="Issuer"} 2
+	certmanager_certificate_chain_expiring_soon_count{issuer_group="example.com",issuer_kind="ExternalIssuer"} 1
+`),
+		"certmanager_certificate_chain_expiring_soon_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+const issuerRefDriftMetadata = `
+	# HELP certmanager_certificate_issuerref_drift_count The number of Certificates whose Secret was issued by a different issuer than the one currently referenced by the Certificate.
+	# TYPE certmanager_certificate_issuerref_drift_count gauge
+`
+
+func TestResyncCertificateIssuerRefDriftCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crtWithIssuer := func(name, namespace string, ref cmmeta.ObjectReference) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace(namespace),
+			gen.SetCertificateSecretName(name+"-tls"),
+			gen.SetCertificateIssuer(ref),
+		)
+	}
+	secretIssuedBy := func(name, namespace string, annotations map[string]string) *corev1.Secret {
+		secret := testSecret(name+"-tls", namespace, nil)
+		secret.Annotations = annotations
+		return secret
+	}
+	issuedBy := func(name, kind, group string) map[string]string {
+		return map[string]string{
+			cmapi.IssuerNameAnnotationKey:  name,
+			cmapi.IssuerKindAnnotationKey:  kind,
+			cmapi.IssuerGroupAnnotationKey: group,
+		}
+	}
+
+	m.Resync(ResyncState{
+		Certificates: []*cmapi.Certificate{
+			crtWithIssuer("same", "ns1", cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "cert-manager.io"}),
+			// An empty kind and group default to Issuer and cert-manager.io.
+			crtWithIssuer("defaulted", "ns1", cmmeta.ObjectReference{Name: "ca"}),
+			crtWithIssuer("renamed", "ns1", cmmeta.ObjectReference{Name: "new-ca", Kind: "Issuer", Group: "cert-manager.io"}),
+			crtWithIssuer("to-cluster-issuer", "ns2", cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer", Group: "cert-manager.io"}),
+			crtWithIssuer("to-external-issuer", "ns2", cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "example.com"}),
+			// Secrets which have not been issued by cert-manager have no
+			// issuer annotations, so are not counted.
+			crtWithIssuer("not-issued", "ns2", cmmeta.ObjectReference{Name: "ca"}),
+		},
+		Secrets: []*corev1.Secret{
+			secretIssuedBy("same", "ns1", issuedBy("ca", "Issuer", "cert-manager.io")),
+			secretIssuedBy("defaulted", "ns1", issuedBy("ca", "Issuer", "cert-manager.io")),
+			secretIssuedBy("renamed", "ns1", issuedBy("ca", "Issuer", "cert-manager.io")),
+			secretIssuedBy("to-cluster-issuer", "ns2", issuedBy("ca", "Issuer", "cert-manager.io")),
+			secretIssuedBy("to-external-issuer", "ns2", issuedBy("ca", "", "")),
+			secretIssuedBy("not-issued", "ns2", nil),
+		},
+	})
+	if err := testutil.CollectAndCompare(m.certificateIssuerRefDriftCount,
+		strings.NewReader(issuerRefDriftMetadata+`
+	certmanager_certificate_issuerref_drift_count{namespace="ns1"} 1
+	certmanager_certificate_issuerref_drift_count{namespace="ns2"} 2
+`),
+		"certmanager_certificate_issuerref_drift_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Counts are reset on each resync.
+	m.Resync(ResyncState{})
+	if n := testutil.CollectAndCount(m.certificateIssuerRefDriftCount); n != 0 {
+		t.Errorf("expected no series after resync with no Certificates, got %d", n)
+	}
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+// SetShimAnnotationConflictCount sets the number of TLS entries of annotated
+// Ingresses for which a Certificate already exists that is not owned by the
+// Ingress, keyed by namespace. ingress-shim refuses to update such
+// Certificates, so the annotations on the Ingress are silently ignored.
+func (m *Metrics) SetShimAnnotationConflictCount(counts map[string]int) {
+	m.setGaugeValues(m.shimAnnotationConflictCount, gaugeValuesFromCounts(counts))
+}
+
+// SetShimMissingCertificateCount sets the number of Certificates which
+// annotated Ingresses and Gateways request but which do not exist, keyed by
+// the namespace they are expected in. TLS is silently not served for these
+// until ingress-shim creates the Certificate.
+func (m *Metrics) SetShimMissingCertificateCount(counts map[string]int) {
+	m.setGaugeValues(m.shimMissingCertificateCount, gaugeValuesFromCounts(counts))
+}
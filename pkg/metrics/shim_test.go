@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/clock"
+)
+
+const shimAnnotationConflictMetadata = `
+	# HELP certmanager_shim_annotation_conflict_count The number of annotated Ingress TLS entries whose Certificate already exists and is not owned by the Ingress, so is not managed by ingress-shim.
+	# TYPE certmanager_shim_annotation_conflict_count gauge
+`
+
+const shimMissingCertificateMetadata = `
+	# HELP certmanager_shim_missing_certificate_count The number of Certificates requested by annotated Ingresses and Gateways which do not exist, by the namespace they are expected in.
+	# TYPE certmanager_shim_missing_certificate_count gauge
+`
+
+func TestSetShimAnnotationConflictCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	m.SetShimAnnotationConflictCount(map[string]int{"ns1": 1, "ns2": 2})
+	if err := testutil.CollectAndCompare(m.shimAnnotationConflictCount,
+		strings.NewReader(shimAnnotationConflictMetadata+`
+	certmanager_shim_annotation_conflict_count{namespace="ns1"} 1
+	certmanager_shim_annotation_conflict_count{namespace="ns2"} 2
+`),
+		"certmanager_shim_annotation_conflict_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestSetShimMissingCertificateCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	// Series from a previous resync which are no longer missing are removed.
+	m.SetShimMissingCertificateCount(map[string]int{"stale": 1})
+
+	m.SetShimMissingCertificateCount(map[string]int{"ns1": 1, "ns2": 1})
+	if err := testutil.CollectAndCompare(m.shimMissingCertificateCount,
+		strings.NewReader(shimMissingCertificateMetadata+`
+	certmanager_shim_missing_certificate_count{namespace="ns1"} 1
+	certmanager_shim_missing_certificate_count{namespace="ns2"} 1
+`),
+		"certmanager_shim_missing_certificate_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Snapshot gathers the current values of the counters and gauges registered
+// with the Metrics registry, keyed by their fully-qualified name and labels,
+// e.g. `certmanager_controller_sync_call_count{controller="issuers"}`.
+// Histograms and summaries are not included. Metrics are only registered once
+// NewServer or Handler has been called, so the snapshot will be empty before
+// then.
+// This is intended to be used for dumping metrics to logs when debugging.
+func (m *Metrics) Snapshot() map[string]float64 {
+	families, err := m.registry.Gather()
+	if err != nil {
+		// Gather returns as many metrics as possible, even on error.
+		m.log.Error(err, "failed to gather some metrics for snapshot")
+	}
+
+	snapshot := make(map[string]float64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			var value float64
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				value = metric.GetCounter().GetValue()
+			case dto.MetricType_GAUGE:
+				value = metric.GetGauge().GetValue()
+			case dto.MetricType_UNTYPED:
+				value = metric.GetUntyped().GetValue()
+			default:
+				continue
+			}
+
+			snapshot[snapshotKey(family.GetName(), metric.GetLabel())] = value
+		}
+	}
+
+	return snapshot
+}
+
+// snapshotKey builds the key for a single series in the same format as the
+// Prometheus text exposition format. Labels are already sorted by name when
+// gathered.
+func snapshotKey(name string, labels []*dto.LabelPair) string {
+	if len(labels) == 0 {
+		return name
+	}
+
+	pairs := make([]string, 0, len(labels))
+	for _, label := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", label.GetName(), label.GetValue()))
+	}
+
+	return name + "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Unix(100, 0))
+	m := New(logtesting.NewTestLogger(t), fixedClock)
+
+	// Metrics are only exposed once registered.
+	assert.Empty(t, m.Snapshot())
+
+	m.registry.MustRegister(m.clockTimeSecondsGauge)
+	m.registry.MustRegister(m.controllerSyncCallCount)
+	m.registry.MustRegister(m.acmeClientRequestDurationSeconds)
+
+	m.IncrementSyncCallCount("issuers")
+	m.IncrementSyncCallCount("issuers")
+	m.IncrementSyncCallCount("certificates")
+	m.ObserveACMERequestDuration(time.Second, "https", "acme.example.com", "/", "GET", "200")
+
+	assert.Equal(t, map[string]float64{
+		"certmanager_clock_time_seconds_gauge":                              100,
+		`certmanager_controller_sync_call_count{controller="certificates"}`: 1,
+		`certmanager_controller_sync_call_count{controller="issuers"}`:      2,
+	}, m.Snapshot())
+}
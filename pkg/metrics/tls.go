@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+)
+
+// NewTLSListener returns a listener which serves TLS over the given listener
+// using config, for use with NewServer. The duration of each successful
+// handshake is observed in the metrics_tls_handshake_duration_seconds metric.
+func (m *Metrics) NewTLSListener(ln net.Listener, config *tls.Config) net.Listener {
+	return tls.NewListener(ln, m.observeTLSHandshakes(config))
+}
+
+// NewServerTLS registers Prometheus metrics and returns a new Prometheus
+// metrics HTTPS server. The server has the same handlers and timeouts as one
+// returned by NewServer, and its TLSConfig is a copy of config, so it should
+// be started with ServeTLS(ln, "", ""). The duration of each successful
+// handshake is observed as for NewTLSListener.
+//
+// To reload the serving certificate when it changes on disk, set
+// config.GetCertificate to the GetCertificate method of a running
+// FileCertificateSource from the webhook's tls package.
+func (m *Metrics) NewServerTLS(ln net.Listener, config *tls.Config) *http.Server {
+	server := m.NewServer(ln)
+	server.TLSConfig = m.observeTLSHandshakes(config)
+	return server
+}
+
+// observeTLSHandshakes returns a copy of config which observes the duration
+// of each handshake, from receiving the ClientHello until the connection has
+// been verified. Any GetConfigForClient and VerifyConnection callbacks in
+// config are preserved.
+func (m *Metrics) observeTLSHandshakes(config *tls.Config) *tls.Config {
+	config = config.Clone()
+	getConfigForClient := config.GetConfigForClient
+
+	config.GetConfigForClient = func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+		start := m.clock.Now()
+
+		connConfig := config
+		if getConfigForClient != nil {
+			c, err := getConfigForClient(hello)
+			if err != nil {
+				return nil, err
+			}
+			if c != nil {
+				connConfig = c
+			}
+		}
+
+		connConfig = connConfig.Clone()
+		verifyConnection := connConfig.VerifyConnection
+		connConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+			if verifyConnection != nil {
+				if err := verifyConnection(cs); err != nil {
+					return err
+				}
+			}
+			m.metricsTLSHandshakeDurationSeconds.Observe(m.clock.Since(start).Seconds())
+			return nil
+		}
+
+		return connConfig, nil
+	}
+
+	return config
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/utils/clock"
+)
+
+func TestNewTLSListener(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tlsLn := m.NewTLSListener(ln, &tls.Config{
+		Certificates: []tls.Certificate{mustSelfSignedCertificate(t)},
+	})
+
+	server := m.NewServer(tlsLn)
+	go func() { _ = server.Serve(tlsLn) }()
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, // #nosec G402 -- self-signed test certificate
+			DisableKeepAlives: true,
+		},
+	}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get("https://" + ln.Addr().String() + "/metrics")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	var metric dto.Metric
+	if err := m.metricsTLSHandshakeDurationSeconds.Write(&metric); err != nil {
+		t.Fatal(err)
+	}
+	if got := metric.GetHistogram().GetSampleCount(); got != 2 {
+		t.Errorf("expected 2 observed handshakes, got %d", got)
+	}
+}
+
+func TestNewServerTLS(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The serving certificate is read on each handshake, so it can be
+	// replaced while the server is running.
+	var (
+		certLock sync.Mutex
+		cert     = mustSelfSignedCertificate(t)
+	)
+	server := m.NewServerTLS(ln, &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			certLock.Lock()
+			defer certLock.Unlock()
+			return &cert, nil
+		},
+	})
+	go func() { _ = server.ServeTLS(ln, "", "") }()
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, // #nosec G402 -- self-signed test certificate
+			DisableKeepAlives: true,
+		},
+	}
+	scrape := func() []byte {
+		resp, err := client.Get("https://" + ln.Addr().String() + "/metrics")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+		}
+		return resp.TLS.PeerCertificates[0].Raw
+	}
+
+	if served := scrape(); !bytes.Equal(served, cert.Certificate[0]) {
+		t.Error("expected the initial certificate to be served")
+	}
+
+	certLock.Lock()
+	cert = mustSelfSignedCertificate(t)
+	certLock.Unlock()
+	if served := scrape(); !bytes.Equal(served, cert.Certificate[0]) {
+		t.Error("expected the replaced certificate to be served")
+	}
+
+	// Plaintext requests are refused.
+	resp, err := http.Get("http://" + ln.Addr().String() + "/metrics")
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			t.Error("expected a plaintext scrape to be refused")
+		}
+	}
+}
+
+func mustSelfSignedCertificate(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/utils/pointer"
+)
+
+// utf8MetricNamesSupported returns true if the Prometheus client library
+// accepts metric names which are not valid legacy, underscore-separated
+// names.
+func utf8MetricNamesSupported() bool {
+	probe := prometheus.NewGauge(prometheus.GaugeOpts{Name: defaultNamespace + ".utf8_probe"})
+	return prometheus.NewRegistry().Register(probe) == nil
+}
+
+// utf8NameGatherer renames the metric families gathered from the wrapped
+// Gatherer to their dot-separated UTF-8 names.
+type utf8NameGatherer struct {
+	prometheus.Gatherer
+}
+
+func (g utf8NameGatherer) Gather() ([]*dto.MetricFamily, error) {
+	mfs, err := g.Gatherer.Gather()
+	for _, mf := range mfs {
+		mf.Name = pointer.String(utf8MetricName(mf.GetName()))
+	}
+	return mfs, err
+}
+
+// utf8MetricName returns the dot-separated UTF-8 name of the metric with the
+// given underscore-separated name.
+func utf8MetricName(name string) string {
+	return strings.ReplaceAll(name, "_", ".")
+}
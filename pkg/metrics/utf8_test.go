@@ -0,0 +1,57 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"k8s.io/utils/clock"
+)
+
+func TestUTF8NameGatherer(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+	m.Handler()
+	m.IncrementSyncCallCount("test")
+
+	mfs, err := utf8NameGatherer{m.registry}.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var found bool
+	for _, mf := range mfs {
+		if mf.GetName() == "certmanager.controller.sync.call.count" {
+			found = true
+		}
+		if mf.GetName() == "certmanager_controller_sync_call_count" {
+			t.Errorf("expected metric to be renamed, got %q", mf.GetName())
+		}
+	}
+	if !found {
+		t.Errorf("expected certmanager.controller.sync.call.count to be gathered")
+	}
+}
+
+func TestWithUTF8MetricNames(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{}, WithUTF8MetricNames(true))
+
+	// UTF-8 metric names are only used when the client library supports them.
+	if m.opts.utf8MetricNames != utf8MetricNamesSupported() {
+		t.Errorf("expected UTF-8 metric names to be used only when supported, got %t", m.opts.utf8MetricNames)
+	}
+}
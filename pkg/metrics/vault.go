@@ -0,0 +1,42 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+const (
+	// VaultIssuanceResultSuccess is the result label value used when Vault
+	// signs a certificate.
+	VaultIssuanceResultSuccess = "success"
+
+	// VaultIssuanceResultError is the result label value used when Vault
+	// fails to sign a certificate.
+	VaultIssuanceResultError = "error"
+)
+
+// IncrementVaultIssuance increases the count of certificates signed by Vault
+// using the given PKI role and path. The role and path are not exposed if
+// Vault issuance labels have been disabled.
+func (m *Metrics) IncrementVaultIssuance(role, path, result string) {
+	if m.opts.disableVaultIssuanceLabels {
+		role, path = "", ""
+	}
+	m.vaultIssuanceCount.WithLabelValues(role, path, result).Inc()
+	m.notifyIssuance(IssuanceEvent{
+		Metric: m.opts.namespace + "_vault_issuance_count",
+		Labels: map[string]string{"role": role, "path": path, "result": result},
+		Value:  1,
+	})
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/clock"
+)
+
+const vaultIssuanceMetadata = `
+	# HELP certmanager_vault_issuance_count The number of certificates signed by Vault, by PKI role, path and result.
+	# TYPE certmanager_vault_issuance_count counter
+`
+
+func TestIncrementVaultIssuance(t *testing.T) {
+	tests := map[string]struct {
+		opts     []Option
+		expected string
+	}{
+		"labels are enabled by default": {
+			expected: `
+	certmanager_vault_issuance_count{path="pki/sign/example",result="error",role="example"} 1
+	certmanager_vault_issuance_count{path="pki/sign/example",result="success",role="example"} 2
+	certmanager_vault_issuance_count{path="pki_int/sign/other",result="success",role="other"} 1
+`,
+		},
+		"labels can be disabled": {
+			opts: []Option{WithVaultIssuanceLabels(false)},
+			expected: `
+	certmanager_vault_issuance_count{path="",result="error",role=""} 1
+	certmanager_vault_issuance_count{path="",result="success",role=""} 3
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := New(logtesting.NewTestLogger(t), clock.RealClock{}, test.opts...)
+
+			m.IncrementVaultIssuance("example", "pki/sign/example", VaultIssuanceResultSuccess)
+			m.IncrementVaultIssuance("example", "pki/sign/example", VaultIssuanceResultSuccess)
+			m.IncrementVaultIssuance("example", "pki/sign/example", VaultIssuanceResultError)
+			m.IncrementVaultIssuance("other", "pki_int/sign/other", VaultIssuanceResultSuccess)
+
+			if err := testutil.CollectAndCompare(m.vaultIssuanceCount,
+				strings.NewReader(vaultIssuanceMetadata+test.expected),
+				"certmanager_vault_issuance_count",
+			); err != nil {
+				t.Errorf("unexpected collecting result:\n%s", err)
+			}
+		})
+	}
+}
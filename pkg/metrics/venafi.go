@@ -24,3 +24,9 @@ import (
 func (m *Metrics) ObserveVenafiRequestDuration(duration time.Duration, labels ...string) {
 	m.venafiClientRequestDurationSeconds.WithLabelValues(labels...).Observe(duration.Seconds())
 }
+
+// IncrementVenafiRequestCount increases the count of requests made by the
+// Venafi client for the given API call which completed with the given status.
+func (m *Metrics) IncrementVenafiRequestCount(apiCall, status string) {
+	m.venafiClientRequestCount.WithLabelValues(apiCall, status).Inc()
+}
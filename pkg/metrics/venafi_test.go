@@ -0,0 +1,47 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/clock"
+)
+
+func TestIncrementVenafiRequestCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	m.IncrementVenafiRequestCount("request_certificate", "success")
+	m.IncrementVenafiRequestCount("retrieve_certificate", "pending")
+	m.IncrementVenafiRequestCount("retrieve_certificate", "pending")
+	m.IncrementVenafiRequestCount("retrieve_certificate", "success")
+	m.IncrementVenafiRequestCount("ping", "error")
+
+	if err := testutil.CollectAndCompare(m.venafiClientRequestCount, strings.NewReader(`
+	# HELP certmanager_http_venafi_client_request_count The number of requests made by the Venafi client, by API call and status.
+	# TYPE certmanager_http_venafi_client_request_count counter
+	certmanager_http_venafi_client_request_count{api_call="ping",status="error"} 1
+	certmanager_http_venafi_client_request_count{api_call="request_certificate",status="success"} 1
+	certmanager_http_venafi_client_request_count{api_call="retrieve_certificate",status="pending"} 2
+	certmanager_http_venafi_client_request_count{api_call="retrieve_certificate",status="success"} 1
+`), "certmanager_http_venafi_client_request_count"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"crypto/tls"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/utils/clock"
+)
+
+// defaultWebhookSlowRequestThreshold is the duration after which a webhook
+// request is counted as slow, unless set using WithWebhookSlowRequestThreshold.
+const defaultWebhookSlowRequestThreshold = time.Second
+
+// webhookOnlyMetricNames are the names, without the namespace, of the
+// metrics recorded only by the webhook. They are not registered by New, so
+// the controller does not expose them without any values.
+var webhookOnlyMetricNames = []string{
+	"webhook_cert_last_reload_timestamp_seconds",
+	"webhook_request_count",
+	"webhook_validation_rules_evaluated",
+	"webhook_panic_recovered_count",
+	"webhook_slow_request_count",
+	"webhook_serving_cert_rotation_count",
+	"webhook_serving_cert_expiration_timestamp_seconds",
+	"webhook_sni_mismatch_count",
+	"webhook_tls_negotiated_count",
+	"conversion_request_object_bytes",
+}
+
+// commonMetricNames are the names, without the namespace, of the metrics
+// recorded by every component, e.g. by the metrics server.
+var commonMetricNames = []string{
+	"logging_verbosity_level",
+	"metrics_scrape_count",
+	"metrics_request_timeout_count",
+	"metrics_server_bind_error_count",
+	"metrics_tls_handshake_duration_seconds",
+}
+
+// NewWebhook creates a Metrics for the webhook. Unlike New, only the metrics
+// recorded by the webhook are registered, so the webhook does not expose the
+// controller's metrics without any values.
+func NewWebhook(log logr.Logger, c clock.Clock, opts ...Option) *Metrics {
+	m := New(log, c, opts...)
+	m.webhook = true
+	return m
+}
+
+// userAgentPattern matches the product and the major and minor version of a
+// User-Agent, e.g. `kube-apiserver/v1.27.3 (linux/amd64) kubernetes/25b4e43`.
+var userAgentPattern = regexp.MustCompile(`^([A-Za-z0-9._-]{1,64})(?:/(v?[0-9]+(?:\.[0-9]+)?))?`)
+
+// SetWebhookCertificateReloaded records that the webhook has just
+// successfully loaded a new serving certificate.
+func (m *Metrics) SetWebhookCertificateReloaded() {
+	m.webhookCertLastReloadTimestampSeconds.Set(float64(m.clock.Now().Unix()))
+}
+
+// IncrementWebhookServingCertificateRotated records that the webhook has
+// generated a new in-memory serving certificate which expires at notAfter.
+func (m *Metrics) IncrementWebhookServingCertificateRotated(notAfter time.Time) {
+	m.webhookServingCertRotationCount.Inc()
+	m.webhookServingCertExpirationTimestampSeconds.Set(float64(notAfter.Unix()))
+}
+
+// IncrementWebhookSNIMismatch increases the count of TLS handshakes with the
+// webhook which requested a server name not covered by its serving
+// certificate.
+func (m *Metrics) IncrementWebhookSNIMismatch() {
+	m.webhookSNIMismatchCount.Inc()
+}
+
+// IncrementWebhookTLSNegotiated increases the count of TLS handshakes with
+// the webhook which negotiated the given TLS version and cipher suite.
+func (m *Metrics) IncrementWebhookTLSNegotiated(version, cipherSuite uint16) {
+	m.webhookTLSNegotiatedCount.WithLabelValues(tlsVersionName(version), tls.CipherSuiteName(cipherSuite)).Inc()
+}
+
+// tlsVersionName returns the name of the given TLS version as accepted by the
+// webhook's --tls-min-version flag, e.g. `VersionTLS12`. Unknown versions are
+// formatted as a hexadecimal value.
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "VersionTLS10"
+	case tls.VersionTLS11:
+		return "VersionTLS11"
+	case tls.VersionTLS12:
+		return "VersionTLS12"
+	case tls.VersionTLS13:
+		return "VersionTLS13"
+	}
+	return fmt.Sprintf("0x%04X", version)
+}
+
+// IncrementWebhookRequest increases the count of requests received by the
+// webhook on the given path. The User-Agent of the caller is normalized to
+// its product and major and minor version to limit the number of series.
+func (m *Metrics) IncrementWebhookRequest(path, userAgent string) {
+	m.webhookRequestCount.WithLabelValues(path, normalizeUserAgent(userAgent)).Inc()
+}
+
+// ObserveWebhookValidationRulesEvaluated observes the number of validation
+// rules evaluated by the webhook for a single admission request for the given
+// resource.
+func (m *Metrics) ObserveWebhookValidationRulesEvaluated(resource string, count int) {
+	m.webhookValidationRulesEvaluated.WithLabelValues(resource).Observe(float64(count))
+}
+
+// ObserveConversionRequestObjectBytes observes the size of a serialized
+// object of the given kind converted by the conversion webhook.
+func (m *Metrics) ObserveConversionRequestObjectBytes(kind string, size int) {
+	m.conversionRequestObjectBytes.WithLabelValues(kind).Observe(float64(size))
+}
+
+// ObserveWebhookRequestDuration records the time taken to handle a request
+// to the given webhook path, counting it as slow if it exceeded the slow
+// request threshold.
+func (m *Metrics) ObserveWebhookRequestDuration(webhook string, duration time.Duration) {
+	if duration > m.opts.webhookSlowRequestThreshold {
+		m.webhookSlowRequestCount.WithLabelValues(webhook).Inc()
+	}
+}
+
+// IncrementWebhookPanicRecovered increases the count of panics recovered
+// while the webhook handled a request on the given handler path.
+func (m *Metrics) IncrementWebhookPanicRecovered(handler string) {
+	m.webhookPanicRecoveredCount.WithLabelValues(handler).Inc()
+}
+
+// normalizeUserAgent reduces a User-Agent to its product and major and minor
+// version, e.g. `kube-apiserver/v1.27`. User-Agents which cannot be parsed
+// are reported as `unknown`.
+func normalizeUserAgent(userAgent string) string {
+	match := userAgentPattern.FindStringSubmatch(strings.TrimSpace(userAgent))
+	if match == nil {
+		return "unknown"
+	}
+	if match[2] == "" {
+		return match[1]
+	}
+	return match[1] + "/" + match[2]
+}
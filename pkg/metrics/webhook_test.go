@@ -0,0 +1,106 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/clock"
+)
+
+func TestNormalizeUserAgent(t *testing.T) {
+	tests := map[string]string{
+		"kube-apiserver/v1.27.3 (linux/amd64) kubernetes/25b4e43": "kube-apiserver/v1.27",
+		"kube-apiserver-admission":                                "kube-apiserver-admission",
+		"Go-http-client/1.1":                                      "Go-http-client/1.1",
+		"curl/8":                                                  "curl/8",
+		"":                                                        "unknown",
+		"(compatible)":                                            "unknown",
+	}
+
+	for userAgent, expected := range tests {
+		if got := normalizeUserAgent(userAgent); got != expected {
+			t.Errorf("expected %q to be normalized to %q, got %q", userAgent, expected, got)
+		}
+	}
+}
+
+func TestIncrementWebhookServingCertificateRotated(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	m.IncrementWebhookServingCertificateRotated(time.Unix(1000, 0))
+	m.IncrementWebhookServingCertificateRotated(time.Unix(2000, 0))
+
+	if err := testutil.CollectAndCompare(m.webhookServingCertRotationCount, strings.NewReader(`
+	# HELP certmanager_webhook_serving_cert_rotation_count The number of times the webhook has generated a new in-memory serving certificate.
+	# TYPE certmanager_webhook_serving_cert_rotation_count counter
+	certmanager_webhook_serving_cert_rotation_count 2
+`), "certmanager_webhook_serving_cert_rotation_count"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+	if err := testutil.CollectAndCompare(m.webhookServingCertExpirationTimestampSeconds, strings.NewReader(`
+	# HELP certmanager_webhook_serving_cert_expiration_timestamp_seconds The date after which the webhook's current in-memory serving certificate expires. Expressed as a Unix Epoch Time.
+	# TYPE certmanager_webhook_serving_cert_expiration_timestamp_seconds gauge
+	certmanager_webhook_serving_cert_expiration_timestamp_seconds 2000
+`), "certmanager_webhook_serving_cert_expiration_timestamp_seconds"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestNewWebhook(t *testing.T) {
+	m := NewWebhook(logtesting.NewTestLogger(t), clock.RealClock{})
+	m.Handler()
+
+	if expected := len(webhookOnlyMetricNames) + len(commonMetricNames); len(m.collectors) != expected {
+		t.Errorf("expected %d metrics to be registered, got %d", expected, len(m.collectors))
+	}
+	for name, c := range m.collectors {
+		if c == nil {
+			t.Errorf("expected metric %s to exist", name)
+		}
+	}
+
+	// Controller metrics are not exposed by the webhook.
+	m.IncrementSyncCallCount("issuers")
+	if n, err := testutil.GatherAndCount(m.registry, "certmanager_controller_sync_call_count"); err != nil || n != 0 {
+		t.Errorf("expected controller metrics not to be exposed, got %d series (err: %v)", n, err)
+	}
+	m.IncrementWebhookSNIMismatch()
+	if n, err := testutil.GatherAndCount(m.registry, "certmanager_webhook_sni_mismatch_count"); err != nil || n != 1 {
+		t.Errorf("expected webhook metrics to be exposed, got %d series (err: %v)", n, err)
+	}
+}
+
+func TestNewDoesNotRegisterWebhookMetrics(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+	m.Handler()
+
+	for _, name := range webhookOnlyMetricNames {
+		if _, ok := m.collectors["certmanager_"+name]; ok {
+			t.Errorf("expected webhook metric %s not to be registered", name)
+		}
+	}
+	for _, name := range commonMetricNames {
+		if _, ok := m.collectors["certmanager_"+name]; !ok {
+			t.Errorf("expected metric %s to be registered", name)
+		}
+	}
+}
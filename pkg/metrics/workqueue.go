@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"k8s.io/client-go/util/workqueue"
+)
+
+// WorkqueueMetricsProvider returns a workqueue.MetricsProvider which records
+// the latency of named workqueues. Workqueues are named after the controller
+// which owns them. The provider must be set with workqueue.SetProvider before
+// any workqueues are created.
+func (m *Metrics) WorkqueueMetricsProvider() workqueue.MetricsProvider {
+	return &workqueueMetricsProvider{metrics: m}
+}
+
+// workqueueMetricsProvider only exposes the latency metric. All other
+// workqueue metrics are discarded.
+type workqueueMetricsProvider struct {
+	metrics *Metrics
+}
+
+func (p *workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return workqueueLatencyMetric{metrics: p.metrics, controllerName: name}
+}
+
+func (p *workqueueMetricsProvider) NewDepthMetric(string) workqueue.GaugeMetric {
+	return noopWorkqueueMetric{}
+}
+
+func (p *workqueueMetricsProvider) NewAddsMetric(string) workqueue.CounterMetric {
+	return noopWorkqueueMetric{}
+}
+
+func (p *workqueueMetricsProvider) NewWorkDurationMetric(string) workqueue.HistogramMetric {
+	return noopWorkqueueMetric{}
+}
+
+func (p *workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(string) workqueue.SettableGaugeMetric {
+	return noopWorkqueueMetric{}
+}
+
+func (p *workqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(string) workqueue.SettableGaugeMetric {
+	return noopWorkqueueMetric{}
+}
+
+func (p *workqueueMetricsProvider) NewRetriesMetric(string) workqueue.CounterMetric {
+	return noopWorkqueueMetric{}
+}
+
+// workqueueLatencyMetric is observed by the workqueue with the number of
+// seconds an item waited in the queue.
+type workqueueLatencyMetric struct {
+	metrics        *Metrics
+	controllerName string
+}
+
+func (w workqueueLatencyMetric) Observe(seconds float64) {
+	w.metrics.controllerWorkqueueLatencySeconds.WithLabelValues(w.controllerName).Observe(seconds)
+}
+
+type noopWorkqueueMetric struct{}
+
+func (noopWorkqueueMetric) Inc()            {}
+func (noopWorkqueueMetric) Dec()            {}
+func (noopWorkqueueMetric) Set(float64)     {}
+func (noopWorkqueueMetric) Observe(float64) {}
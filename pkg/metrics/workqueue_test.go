@@ -0,0 +1,40 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/clock"
+)
+
+func TestWorkqueueMetricsProvider(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+	provider := m.WorkqueueMetricsProvider()
+
+	provider.NewLatencyMetric("certificates-issuing").Observe(0.5)
+	provider.NewLatencyMetric("certificates-issuing").Observe(1.5)
+	provider.NewLatencyMetric("issuers").Observe(2)
+	// Other workqueue metrics are discarded.
+	provider.NewWorkDurationMetric("issuers").Observe(3)
+
+	if count := testutil.CollectAndCount(m.controllerWorkqueueLatencySeconds); count != 2 {
+		t.Errorf("expected 2 controller series, got %d", count)
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+// knownDNS01Providers are the names of the DNS01 providers built in to
+// cert-manager, as used for the provider label.
+var knownDNS01Providers = [...]string{
+	"acmedns",
+	"akamai",
+	"azuredns",
+	"clouddns",
+	"cloudflare",
+	"digitalocean",
+	"rfc2136",
+	"route53",
+	"webhook",
+}
+
+// initZeroValuedSeries creates a zero-valued series for each known label value
+// of the counters which are commonly alerted on, so that rate() and
+// increase() return zero rather than no data before the first increment.
+func (m *Metrics) initZeroValuedSeries() {
+	for _, controllerName := range m.opts.zeroValuedSeriesControllers {
+		m.controllerSyncCallCount.WithLabelValues(controllerName)
+		m.controllerSyncErrorCount.WithLabelValues(controllerName)
+	}
+
+	for _, provider := range knownDNS01Providers {
+		m.acmeDNS01RateLimitedCount.WithLabelValues(provider)
+	}
+}
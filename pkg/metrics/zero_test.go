@@ -0,0 +1,43 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/clock"
+)
+
+func TestWithZeroValuedSeries(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+	if n := testutil.CollectAndCount(m.controllerSyncErrorCount); n != 0 {
+		t.Errorf("expected no series by default, got %d", n)
+	}
+
+	m = New(logtesting.NewTestLogger(t), clock.RealClock{}, WithZeroValuedSeries("issuers", "certificates-trigger"))
+	if n := testutil.CollectAndCount(m.controllerSyncErrorCount); n != 2 {
+		t.Errorf("expected a series for each controller, got %d", n)
+	}
+	if v := testutil.ToFloat64(m.controllerSyncErrorCount.WithLabelValues("issuers")); v != 0 {
+		t.Errorf("expected series to be zero-valued, got %v", v)
+	}
+	if n := testutil.CollectAndCount(m.acmeDNS01RateLimitedCount); n != len(knownDNS01Providers) {
+		t.Errorf("expected a series for each DNS01 provider, got %d", n)
+	}
+}
@@ -55,6 +55,21 @@ func (pc PluginChain) Validate(ctx context.Context, request admissionv1.Admissio
 	return allWarnings, utilerrors.NewAggregate(allErrors)
 }
 
+// validators returns the number of plugins in the chain which validate the
+// given operation.
+func (pc PluginChain) validators(operation admissionv1.Operation) int {
+	count := 0
+	for _, handler := range pc {
+		if !handler.Handles(operation) {
+			continue
+		}
+		if _, ok := handler.(ValidationInterface); ok {
+			count++
+		}
+	}
+	return count
+}
+
 func (pc PluginChain) Mutate(ctx context.Context, request admissionv1.AdmissionRequest, obj runtime.Object) error {
 	for _, handler := range pc {
 		if !handler.Handles(request.Operation) {
@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	apijson "k8s.io/apimachinery/pkg/runtime/serializer/json"
 
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/webhook/handlers"
 )
 
@@ -62,6 +63,10 @@ type RequestHandler struct {
 
 	validator ValidationInterface
 	mutator   MutationInterface
+
+	// Metrics, if set, is used to record the number of validation rules
+	// evaluated for each admission request.
+	Metrics *metrics.Metrics
 }
 
 // NewRequestHandler will construct a new request handler using the given scheme for
@@ -112,6 +117,7 @@ func (rh *RequestHandler) Validate(ctx context.Context, admissionSpec *admission
 
 	warnings, err := rh.validator.Validate(ctx, *admissionSpec, oldObj, obj)
 	status.Warnings = warnings
+	rh.observeValidationRulesEvaluated(admissionSpec)
 
 	// return with allowed = false if any errors occurred
 	if err != nil {
@@ -272,3 +278,18 @@ func sortOps(ops []jsonpatch.JsonPatchOperation) {
 		return ops[i].Path < ops[j].Path
 	})
 }
+
+// observeValidationRulesEvaluated records the number of validation rules
+// evaluated for the request. Each validating plugin in a chain counts as one
+// rule, and a validator which is not a chain counts as a single rule.
+func (rh *RequestHandler) observeValidationRulesEvaluated(admissionSpec *admissionv1.AdmissionRequest) {
+	if rh.Metrics == nil {
+		return
+	}
+
+	count := 1
+	if chain, ok := rh.validator.(PluginChain); ok {
+		count = chain.validators(admissionSpec.Operation)
+	}
+	rh.Metrics.ObserveWebhookValidationRulesEvaluated(admissionSpec.Resource.Resource, count)
+}
@@ -21,16 +21,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"gomodules.xyz/jsonpatch/v2"
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/diff"
 
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 	"github.com/cert-manager/cert-manager/pkg/webhook/handlers/testdata/apis/testgroup"
 	"github.com/cert-manager/cert-manager/pkg/webhook/handlers/testdata/apis/testgroup/install"
@@ -280,6 +285,54 @@ func TestRequestHandler_ValidateReturnsErrorsAndWarnings(t *testing.T) {
 	}
 }
 
+func TestRequestHandler_ValidateObservesRulesEvaluated(t *testing.T) {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+
+	m := metrics.NewWebhook(logr.Discard(), clock.RealClock{})
+	rh := admission.NewRequestHandler(scheme, admission.PluginChain{
+		testValidator{handles: true},
+		testValidator{handles: true},
+		// Validators which do not handle the operation are not evaluated.
+		testValidator{handles: false},
+	}, nil)
+	rh.Metrics = m
+
+	inputRequest := admissionv1.AdmissionRequest{
+		UID:       types.UID("abc"),
+		Operation: admissionv1.Create,
+		Resource: metav1.GroupVersionResource{
+			Group:    "testgroup.testing.cert-manager.io",
+			Version:  "v1",
+			Resource: "testtypes",
+		},
+		Object: runtime.RawExtension{
+			Raw: []byte(`
+{
+	"apiVersion": "testgroup.testing.cert-manager.io/v1",
+	"kind": "TestType",
+	"metadata": {
+		"name": "testing",
+		"namespace": "abc"
+	}
+}
+`),
+		},
+	}
+	rh.Validate(context.TODO(), &inputRequest)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, expected := range []string{
+		`certmanager_webhook_validation_rules_evaluated_sum{resource="testtypes"} 2`,
+		`certmanager_webhook_validation_rules_evaluated_count{resource="testtypes"} 1`,
+	} {
+		if !strings.Contains(rec.Body.String(), expected) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", expected, rec.Body.String())
+		}
+	}
+}
+
 func responseForOperations(ops ...jsonpatch.JsonPatchOperation) []byte {
 	b, err := json.Marshal(ops)
 	if err != nil {
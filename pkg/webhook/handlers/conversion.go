@@ -31,12 +31,16 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/serializer/versioning"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
 type SchemeBackedConverter struct {
 	log        logr.Logger
 	scheme     *runtime.Scheme
 	serializer *apijson.Serializer
+
+	// Metrics, if set, is used to record the size of each object converted.
+	Metrics *metrics.Metrics
 }
 
 var _ ConversionHook = &SchemeBackedConverter{}
@@ -77,6 +81,9 @@ func (c *SchemeBackedConverter) convertObjects(desiredAPIVersion string, objects
 			return nil, fmt.Errorf("Failed to decode into apiVersion: %v", err)
 		}
 		c.log.V(logf.DebugLevel).Info("Decoded resource", "decoded_group_version_kind", currentGVK)
+		if c.Metrics != nil {
+			c.Metrics.ObserveConversionRequestObjectBytes(currentGVK.Kind, len(raw.Raw))
+		}
 		buf := bytes.Buffer{}
 		if err := codec.Encode(decodedObject, &buf); err != nil {
 			return nil, fmt.Errorf("Failed to convert to desired apiVersion: %v", err)
@@ -17,15 +17,21 @@ limitations under the License.
 package handlers
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2/klogr"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/diff"
 
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/webhook/handlers/testdata/apis/testgroup"
 	"github.com/cert-manager/cert-manager/pkg/webhook/handlers/testdata/apis/testgroup/install"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -224,3 +230,32 @@ func TestConvertTestType(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertObservesObjectBytes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+
+	m := metrics.NewWebhook(klogr.New(), clock.RealClock{})
+	c := NewSchemeBackedConverter(klogr.New(), scheme)
+	c.Metrics = m
+
+	raw := []byte(`{"apiVersion":"testgroup.testing.cert-manager.io/v1","kind":"TestType","metadata":{"name":"testing","namespace":"abc"}}`)
+	resp := c.Convert(&apiextensionsv1.ConversionRequest{
+		DesiredAPIVersion: testgroup.GroupName + "/v1",
+		Objects:           []runtime.RawExtension{{Raw: raw}, {Raw: raw}},
+	})
+	if resp.Result.Status != metav1.StatusSuccess {
+		t.Fatalf("unexpected conversion result: %v", resp.Result)
+	}
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, line := range []string{
+		`certmanager_conversion_request_object_bytes_count{kind="TestType"} 2`,
+		fmt.Sprintf(`certmanager_conversion_request_object_bytes_sum{kind="TestType"} %d`, 2*len(raw)),
+	} {
+		if !strings.Contains(rec.Body.String(), line) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", line, rec.Body.String())
+		}
+	}
+}
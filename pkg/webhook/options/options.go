@@ -60,6 +60,12 @@ func NewWebhookConfiguration() (*config.WebhookConfiguration, error) {
 func AddConfigFlags(fs *pflag.FlagSet, c *config.WebhookConfiguration) {
 	fs.Int32Var(&c.SecurePort, "secure-port", c.SecurePort, "port number to listen on for secure TLS connections")
 	fs.Int32Var(&c.HealthzPort, "healthz-port", c.HealthzPort, "port number to listen on for insecure healthz connections")
+	fs.StringVar(&c.MetricsListenAddress, "metrics-listen-address", c.MetricsListenAddress, "The host and port that the metrics endpoint should listen on. If not specified, metrics will not be exposed.")
+	fs.BoolVar(&c.ServeMetricsOnSecurePort, "serve-metrics-on-secure-port", c.ServeMetricsOnSecurePort, "Serve the metrics endpoint at /metrics on the secure port, using the webhook's TLS configuration, instead of on a separate listener.")
+	fs.BoolVar(&c.MetricsAuthentication.Enabled, "enable-metrics-authentication", c.MetricsAuthentication.Enabled, "Require scrapes of the metrics endpoint to present a bearer token, which is validated using the Kubernetes TokenReview API.")
+	fs.StringVar(&c.MetricsAuthentication.TokenHeaderName, "metrics-authentication-token-header", c.MetricsAuthentication.TokenHeaderName, "The request header which carries the bearer token of metrics scrapes, with an optional 'Bearer ' prefix.")
+	fs.StringSliceVar(&c.MetricsAuthentication.Audiences, "metrics-authentication-audiences", c.MetricsAuthentication.Audiences, "The audiences which the bearer token of metrics scrapes must be valid for. If not specified, the audiences of the API server are used.")
+	fs.DurationVar(&c.SlowRequestThreshold, "slow-request-threshold", c.SlowRequestThreshold, "The duration after which a webhook request is counted as slow in the webhook_slow_request_count metric.")
 
 	fs.StringVar(&c.TLSConfig.Filesystem.CertFile, "tls-cert-file", c.TLSConfig.Filesystem.CertFile, "path to the file containing the TLS certificate to serve with")
 	fs.StringVar(&c.TLSConfig.Filesystem.KeyFile, "tls-private-key-file", c.TLSConfig.Filesystem.KeyFile, "path to the file containing the TLS private key to serve with")
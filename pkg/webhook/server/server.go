@@ -19,11 +19,13 @@ package server
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"runtime/debug"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -39,6 +41,7 @@ import (
 	ciphers "k8s.io/component-base/cli/flag"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/util/profiling"
 	"github.com/cert-manager/cert-manager/pkg/webhook/handlers"
 	servertls "github.com/cert-manager/cert-manager/pkg/webhook/server/tls"
@@ -82,6 +85,18 @@ type Server struct {
 	// If not specified, the healthz endpoint will not be exposed.
 	HealthzAddr string
 
+	// MetricsAddr is the address the metrics HTTP server should listen on.
+	// If not specified, or if Metrics is not set, metrics will not be exposed.
+	MetricsAddr string
+
+	// Metrics is used to record and expose the webhook's Prometheus metrics.
+	Metrics *metrics.Metrics
+
+	// MetricsOnListener serves the metrics endpoint at /metrics on the
+	// webhook's own listener, sharing its TLS configuration, instead of on
+	// MetricsAddr. Metrics must be set.
+	MetricsOnListener bool
+
 	// PprofAddr is the address the pprof endpoint should be served on if enabled.
 	PprofAddr string
 	// EnablePprof determines whether pprof is enabled.
@@ -153,6 +168,33 @@ func (s *Server) Run(ctx context.Context) error {
 		})
 	}
 
+	// if a MetricsAddr is provided, start the metrics listener
+	if s.MetricsAddr != "" && s.Metrics != nil && !s.MetricsOnListener {
+		server, metricsListener, err := s.Metrics.NewServerE(s.MetricsAddr)
+		if err != nil {
+			return err
+		}
+
+		s.log.V(logf.InfoLevel).Info("listening for insecure metrics connections", "address", s.MetricsAddr)
+		g.Go(func() error {
+			<-gctx.Done()
+			// allow a timeout for graceful shutdown
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if err := server.Shutdown(ctx); err != nil {
+				return err
+			}
+			return nil
+		})
+		g.Go(func() error {
+			if err := server.Serve(metricsListener); err != http.ErrServerClosed {
+				return err
+			}
+			return nil
+		})
+	}
+
 	// if a PprofAddr is provided, start the pprof listener
 	if s.EnablePprof {
 		pprofListener, err := net.Listen("tcp", s.PprofAddr)
@@ -210,7 +252,8 @@ func (s *Server) Run(ctx context.Context) error {
 			return err
 		}
 		listener = tls.NewListener(listener, &tls.Config{
-			GetCertificate:           s.CertificateSource.GetCertificate,
+			GetCertificate:           s.getCertificate,
+			VerifyConnection:         s.verifyConnection,
 			CipherSuites:             cipherSuites,
 			MinVersion:               minVersion,
 			PreferServerCipherSuites: true,
@@ -224,6 +267,9 @@ func (s *Server) Run(ctx context.Context) error {
 	serverMux.HandleFunc("/validate", s.handle(s.validate))
 	serverMux.HandleFunc("/mutate", s.handle(s.mutate))
 	serverMux.HandleFunc("/convert", s.handle(s.convert))
+	if s.MetricsOnListener && s.Metrics != nil {
+		serverMux.Handle("/metrics", s.Metrics.MetricsHandler())
+	}
 	server := &http.Server{
 		Handler: serverMux,
 	}
@@ -314,9 +360,59 @@ func (s *Server) convert(_ context.Context, obj runtime.Object) (runtime.Object,
 	}
 }
 
+// getCertificate returns the serving certificate from the CertificateSource,
+// counting handshakes which request a server name it does not cover.
+func (s *Server) getCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert, err := s.CertificateSource.GetCertificate(hello)
+	if err != nil || cert == nil || s.Metrics == nil || hello.ServerName == "" {
+		return cert, err
+	}
+	if !certificateCoversServerName(cert, hello.ServerName) {
+		s.Metrics.IncrementWebhookSNIMismatch()
+	}
+	return cert, nil
+}
+
+// verifyConnection counts the TLS version and cipher suite negotiated by each
+// handshake with the webhook. It never rejects a connection.
+func (s *Server) verifyConnection(cs tls.ConnectionState) error {
+	if s.Metrics != nil {
+		s.Metrics.IncrementWebhookTLSNegotiated(cs.Version, cs.CipherSuite)
+	}
+	return nil
+}
+
+// certificateCoversServerName returns false if the leaf of the given
+// certificate is not valid for the given server name. Certificates which
+// cannot be parsed are assumed to cover it.
+func certificateCoversServerName(cert *tls.Certificate, serverName string) bool {
+	leaf := cert.Leaf
+	if leaf == nil {
+		if len(cert.Certificate) == 0 {
+			return true
+		}
+		var err error
+		leaf, err = x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return true
+		}
+	}
+	return leaf.VerifyHostname(serverName) == nil
+}
+
 func (s *Server) handle(inner handleFunc) func(w http.ResponseWriter, req *http.Request) {
 	return func(w http.ResponseWriter, req *http.Request) {
 		defer req.Body.Close()
+		defer s.recoverPanic(w, req)
+
+		if s.Metrics != nil {
+			s.Metrics.IncrementWebhookRequest(req.URL.Path, req.UserAgent())
+
+			start := time.Now()
+			defer func() {
+				s.Metrics.ObserveWebhookRequestDuration(req.URL.Path, time.Since(start))
+			}()
+		}
 
 		data, err := io.ReadAll(req.Body)
 		if err != nil {
@@ -349,6 +445,27 @@ func (s *Server) handle(inner handleFunc) func(w http.ResponseWriter, req *http.
 	}
 }
 
+// recoverPanic recovers a panic raised while handling a webhook request, so
+// that it is logged and counted rather than only being logged by net/http.
+// The request fails with an internal server error.
+func (s *Server) recoverPanic(w http.ResponseWriter, req *http.Request) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	// ErrAbortHandler is used to deliberately abort a response, and is not
+	// logged by net/http.
+	if r == http.ErrAbortHandler {
+		panic(r)
+	}
+
+	s.log.Error(fmt.Errorf("%v", r), "recovered from panic while handling webhook request", "path", req.URL.Path, "stack", string(debug.Stack()))
+	if s.Metrics != nil {
+		s.Metrics.IncrementWebhookPanicRecovered(req.URL.Path)
+	}
+	w.WriteHeader(http.StatusInternalServerError)
+}
+
 func (s *Server) handleHealthz(w http.ResponseWriter, req *http.Request) {
 	defer req.Body.Close()
 
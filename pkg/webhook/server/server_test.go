@@ -19,8 +19,19 @@ package server
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -30,9 +41,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/webhook/handlers"
+	servertls "github.com/cert-manager/cert-manager/pkg/webhook/server/tls"
 	"k8s.io/klog/v2/klogr"
 )
 
@@ -215,3 +229,103 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleRecoversPanics(t *testing.T) {
+	m := metrics.NewWebhook(logr.Discard(), clock.RealClock{})
+	// Metrics are only exposed in a snapshot once registered.
+	m.Handler()
+	s := &Server{log: logr.Discard(), Metrics: m}
+
+	handler := s.handle(func(context.Context, runtime.Object) (runtime.Object, error) {
+		panic("something went wrong")
+	})
+
+	body := `{"apiVersion": "admission.k8s.io/v1", "kind": "AdmissionReview", "request": {}}`
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, 1.0, m.Snapshot()[`certmanager_webhook_panic_recovered_count{handler="/validate"}`])
+}
+
+func TestHandleCountsSlowRequests(t *testing.T) {
+	m := metrics.NewWebhook(logr.Discard(), clock.RealClock{}, metrics.WithWebhookSlowRequestThreshold(10*time.Millisecond))
+	// Metrics are only exposed in a snapshot once registered.
+	m.Handler()
+	s := &Server{log: logr.Discard(), Metrics: m}
+
+	body := `{"apiVersion": "admission.k8s.io/v1", "kind": "AdmissionReview", "request": {}}`
+	for _, delay := range []time.Duration{0, 20 * time.Millisecond} {
+		delay := delay
+		handler := s.handle(func(_ context.Context, obj runtime.Object) (runtime.Object, error) {
+			time.Sleep(delay)
+			return obj, nil
+		})
+		handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(body)))
+	}
+
+	assert.Equal(t, 1.0, m.Snapshot()[`certmanager_webhook_slow_request_count{webhook="/validate"}`])
+}
+
+type staticCertificateSource struct {
+	servertls.CertificateSource
+	cert *tls.Certificate
+}
+
+func (s staticCertificateSource) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return s.cert, nil
+}
+
+func TestGetCertificateCountsSNIMismatches(t *testing.T) {
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		DNSNames:     []string{"cert-manager-webhook.cert-manager.svc", "*.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pk.Public(), pk)
+	require.NoError(t, err)
+
+	m := metrics.NewWebhook(logr.Discard(), clock.RealClock{})
+	// Metrics are only exposed in a snapshot once registered.
+	m.Handler()
+	s := &Server{
+		log:               logr.Discard(),
+		Metrics:           m,
+		CertificateSource: staticCertificateSource{cert: &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: pk}},
+	}
+
+	for _, serverName := range []string{
+		"cert-manager-webhook.cert-manager.svc",
+		"foo.example.com",
+		"cert-manager-webhook.default.svc",
+		// Clients which do not send SNI are not counted.
+		"",
+	} {
+		_, err := s.getCertificate(&tls.ClientHelloInfo{ServerName: serverName})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1.0, m.Snapshot()["certmanager_webhook_sni_mismatch_count"])
+}
+
+func TestVerifyConnectionCountsNegotiatedTLS(t *testing.T) {
+	m := metrics.NewWebhook(logr.Discard(), clock.RealClock{})
+	// Metrics are only exposed in a snapshot once registered.
+	m.Handler()
+	s := &Server{log: logr.Discard(), Metrics: m}
+
+	for _, cs := range []tls.ConnectionState{
+		{Version: tls.VersionTLS13, CipherSuite: tls.TLS_AES_128_GCM_SHA256},
+		{Version: tls.VersionTLS13, CipherSuite: tls.TLS_AES_128_GCM_SHA256},
+		{Version: tls.VersionTLS12, CipherSuite: tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+	} {
+		require.NoError(t, s.verifyConnection(cs))
+	}
+
+	snapshot := m.Snapshot()
+	assert.Equal(t, 2.0, snapshot[`certmanager_webhook_tls_negotiated_count{cipher="TLS_AES_128_GCM_SHA256",tls_version="VersionTLS13"}`])
+	assert.Equal(t, 1.0, snapshot[`certmanager_webhook_tls_negotiated_count{cipher="TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",tls_version="VersionTLS12"}`])
+}
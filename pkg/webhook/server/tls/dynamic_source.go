@@ -30,6 +30,7 @@ import (
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/pkg/webhook/authority"
 )
@@ -43,6 +44,10 @@ type DynamicSource struct {
 	// The authority used to sign certificate templates.
 	Authority *authority.DynamicAuthority
 
+	// Metrics is used to record when the serving certificate is regenerated.
+	// If not specified, no metrics will be recorded.
+	Metrics *metrics.Metrics
+
 	log logr.Logger
 
 	cachedCertificate *tls.Certificate
@@ -249,6 +254,10 @@ func (f *DynamicSource) updateCertificate(pk crypto.Signer, cert *x509.Certifica
 	}
 
 	f.cachedCertificate = &bundle
+	if f.Metrics != nil {
+		f.Metrics.SetWebhookCertificateReloaded()
+		f.Metrics.IncrementWebhookServingCertificateRotated(cert.NotAfter)
+	}
 	certDuration := cert.NotAfter.Sub(cert.NotBefore)
 	// renew the certificate 1/3 of the time before its expiry
 	nextRenew <- cert.NotAfter.Add(certDuration / -3)
@@ -28,6 +28,7 @@ import (
 	"github.com/go-logr/logr"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
 // FileCertificateSource provides certificate data for a golang HTTP server by
@@ -53,6 +54,10 @@ type FileCertificateSource struct {
 	// If not specified, a default of 12 will be used.
 	MaxFailures int
 
+	// Metrics is used to record when the certificate is reloaded from disk.
+	// If not specified, no metrics will be recorded.
+	Metrics *metrics.Metrics
+
 	log logr.Logger
 
 	cachedCertificate *tls.Certificate
@@ -147,6 +152,9 @@ func (f *FileCertificateSource) updateCertificateFromDisk() error {
 	f.cachedCertBytes = certData
 	f.cachedKeyBytes = keyData
 	f.cachedCertificate = &cert
+	if f.Metrics != nil {
+		f.Metrics.SetWebhookCertificateReloaded()
+	}
 
 	return nil
 }